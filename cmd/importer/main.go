@@ -0,0 +1,106 @@
+// Command importer seeds the catalog from local IMDb datasets (title.basics, name.basics and title.principals
+// TSV files, gzipped or not). The database must be migrated by the server first.
+//
+//	go run ./cmd/importer -titles title.basics.tsv.gz -names name.basics.tsv.gz -principals title.principals.tsv.gz
+//
+// Records are matched by IMDb ids, so running it again updates the catalog. An interrupted import continues
+// from the last saved batch, -restart reads the files from the beginning.
+package main
+
+import (
+	"compress/gzip"
+	"context"
+	"flag"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/joho/godotenv"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"vk-backend/internal/repository"
+	"vk-backend/internal/service/imdb"
+)
+
+func main() {
+	titles := flag.String("titles", "", "path to title.basics.tsv[.gz]")
+	names := flag.String("names", "", "path to name.basics.tsv[.gz]")
+	principals := flag.String("principals", "", "path to title.principals.tsv[.gz]")
+	restart := flag.Bool("restart", false, "forget the progress of previous runs")
+	flag.Parse()
+
+	logger := log.New()
+	logger.SetLevel(log.InfoLevel)
+	logger.SetFormatter(&log.TextFormatter{})
+	if err := godotenv.Load(); err != nil {
+		logger.Warnf("failed to load env variables: %v", err)
+	}
+	if *titles == "" && *names == "" && *principals == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	pool, err := pgxpool.New(ctx, os.Getenv("DB_URL"))
+	if err != nil {
+		logger.Fatalf("failed to create new pool: %v", err)
+	}
+	defer pool.Close()
+
+	srv := imdb.NewService(repository.NewDatasetRepository(pool, logger))
+	if *restart {
+		if err := srv.Reset(ctx); err != nil {
+			logger.Fatalf("failed to restart import: %v", err)
+		}
+	}
+
+	var files imdb.Files
+	for _, f := range []struct {
+		path string
+		r    *io.Reader
+	}{{*titles, &files.Titles}, {*names, &files.Names}, {*principals, &files.Principals}} {
+		if f.path == "" {
+			continue
+		}
+		r, closeFile, err := openDataset(f.path)
+		if err != nil {
+			logger.Fatalf("failed to open dataset: %v", err)
+		}
+		defer closeFile()
+		*f.r = r
+	}
+
+	err = srv.Import(ctx, files, func(p imdb.Progress) {
+		state := "importing"
+		if p.Done {
+			state = "imported"
+		}
+		logger.Infof("%s %s: %d lines, %d rows written, %d lines skipped", state, p.Source, p.Lines, p.Written, p.Skipped)
+	})
+	if err != nil {
+		logger.Fatalf("import stopped, run again to resume: %v", err)
+	}
+	logger.Info("import is successful")
+}
+
+// openDataset opens the file and unpacks it if it's gzipped, as the datasets are published
+func openDataset(path string) (io.Reader, func(), error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !strings.HasSuffix(path, ".gz") {
+		return file, func() { _ = file.Close() }, nil
+	}
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, nil, fmt.Errorf("failed to unpack %s: %w", path, err)
+	}
+	return gz, func() { _ = gz.Close(); _ = file.Close() }, nil
+}
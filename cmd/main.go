@@ -20,7 +20,13 @@ import (
 	"vk-backend/internal/api/server"
 	"vk-backend/internal/repository"
 	"vk-backend/internal/service/actor"
+	"vk-backend/internal/service/audit"
+	"vk-backend/internal/service/collection"
+	"vk-backend/internal/service/genre"
+	"vk-backend/internal/service/importer"
 	"vk-backend/internal/service/movie"
+	"vk-backend/internal/service/review"
+	"vk-backend/internal/service/stats"
 	"vk-backend/internal/service/user"
 )
 
@@ -67,13 +73,25 @@ func main() {
 
 	actRepo := repository.NewActorRepository(pool, logger)
 	movieRepo := repository.NewMovieRepository(pool, logger)
+	genreRepo := repository.NewGenreRepository(pool, logger)
+	reviewRepo := repository.NewReviewRepository(pool, logger)
+	collectionRepo := repository.NewCollectionRepository(pool, logger)
+	statsRepo := repository.NewStatsRepository(pool, logger)
+	importRepo := repository.NewImportRepository(pool, logger)
+	auditRepo := repository.NewAuditRepository(pool, logger)
 	userRepo := repository.NewUserRepository(pool, logger)
 
 	actSrv := actor.NewService(actRepo)
 	movieSrv := movie.NewService(movieRepo)
+	genreSrv := genre.NewService(genreRepo)
+	reviewSrv := review.NewService(reviewRepo)
+	collectionSrv := collection.NewService(collectionRepo)
+	statsSrv := stats.NewService(statsRepo)
+	importSrv := importer.NewService(importRepo)
+	auditSrv := audit.NewService(auditRepo)
 	userSrv := user.NewService(userRepo)
 
-	srv := server.New(os.Getenv("HTTP_PORT"), &actSrv, &movieSrv, &userSrv, logger)
+	srv := server.New(os.Getenv("HTTP_PORT"), &actSrv, &movieSrv, &genreSrv, &reviewSrv, &collectionSrv, &statsSrv, &importSrv, &auditSrv, &userSrv, logger)
 	go func() {
 		logger.Println("starting server...")
 		if err := srv.Run(); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -2,10 +2,15 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 	"vk-backend/internal/domain"
+	"vk-backend/internal/service/actor"
 )
 
 type ActorRequest struct {
@@ -19,6 +24,53 @@ type ActorDTO struct {
 	Name      string    `json:"name"`
 	Gender    string    `json:"gender"`
 	BirthDate time.Time `json:"birth_date"`
+	Movies    []RoleDTO `json:"movies,omitempty"` // only with include=movies
+
+	MovieCount int        `json:"movie_count,omitempty"` // only in actor listings
+	DeletedAt  *time.Time `json:"deleted_at,omitempty"`  // only in the trash
+	CreatedAt  *time.Time `json:"created_at,omitempty"`  // not in casts
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+// RoleDTO is a movie in the filmography of an actor
+type RoleDTO struct {
+	Id          int       `json:"id"`
+	Title       string    `json:"title"`
+	ReleaseDate time.Time `json:"release_date"`
+	Rating      float64   `json:"rating"`
+	Score       float64   `json:"score"`
+	Character   string    `json:"character"`
+	Billing     int       `json:"billing"`
+}
+
+// CostarDTO is an actor who played with another one in SharedMovies movies
+type CostarDTO struct {
+	ActorDTO
+	SharedMovies int `json:"shared_movies"`
+}
+
+type CostarListDTO struct {
+	Costars    []CostarDTO `json:"costars"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+// PathMovieDTO is a movie connecting two neighbouring actors of a path
+type PathMovieDTO struct {
+	Id          int       `json:"id"`
+	Title       string    `json:"title"`
+	ReleaseDate time.Time `json:"release_date"`
+}
+
+// ActorPathDTO lists actors of the path in order, movies[i] connects actors[i] and actors[i+1]
+type ActorPathDTO struct {
+	Degrees int            `json:"degrees"`
+	Actors  []ActorDTO     `json:"actors"`
+	Movies  []PathMovieDTO `json:"movies"`
+}
+
+type ActorListDTO struct {
+	Actors     []ActorDTO `json:"actors"`
+	NextCursor string     `json:"next_cursor,omitempty"`
 }
 
 func (h *Handler) AddActorHandler(writer http.ResponseWriter, request *http.Request) {
@@ -50,9 +102,11 @@ func (h *Handler) AddActorHandler(writer http.ResponseWriter, request *http.Requ
 	}
 }
 
+// UpdateActorHandler replaces the actor with the one in the body, fields left out are cleared,
+// so the actor is validated as a whole
 func (h *Handler) UpdateActorHandler(writer http.ResponseWriter, request *http.Request) {
 	act := &ActorRequest{}
-	if err := json.NewDecoder(request.Body).Decode(act); err != nil {
+	if err := decodeStrict(request.Body, act); err != nil {
 		writer.WriteHeader(http.StatusBadRequest)
 		_, _ = writer.Write([]byte("Invalid request body"))
 		return
@@ -70,27 +124,73 @@ func (h *Handler) UpdateActorHandler(writer http.ResponseWriter, request *http.R
 		return
 	}
 
-	actor, err := h.act.GetActorById(request.Context(), id)
+	version, err := parseIfMatch(request, h.actorVersion, id)
 	if err != nil {
 		h.HandleServiceError(writer, err)
 		return
 	}
 
-	if act.Name != "" {
-		actor.Name = act.Name
+	h.saveActor(writer, request, id, version, act)
+}
+
+// PatchActorHandler changes the fields of the actor given in the JSON Merge Patch, null clears the field
+func (h *Handler) PatchActorHandler(writer http.ResponseWriter, request *http.Request) {
+	if !isAdminRole(request) {
+		h.HandleServiceError(writer, domain.ErrNotAdmin)
+		return
+	}
+
+	id, err := strconv.Atoi(request.PathValue("id"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid actor id"))
+		return
+	}
+
+	if !isMergePatch(request) {
+		writer.Header().Set("Accept-Patch", mergePatchType)
+		writer.WriteHeader(http.StatusUnsupportedMediaType)
+		_, _ = writer.Write([]byte("Request body must be " + mergePatchType))
+		return
 	}
-	if act.Gender != "" {
-		actor.Gender = genderStringToInt(act.Gender)
+
+	version, err := parseIfMatch(request, h.actorVersion, id)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
 	}
-	if !act.BirthDate.IsZero() {
-		actor.BirthDate = act.BirthDate
+
+	actor, err := h.act.GetActorById(request.Context(), id)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
 	}
 
+	act := &ActorRequest{}
+	if err := applyMergePatch(actorToRequest(actor), request.Body, act); err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid request body"))
+		return
+	}
+
+	h.saveActor(writer, request, id, version, act)
+}
+
+// saveActor updates the actor to the state in the request unless it was changed since the version
+func (h *Handler) saveActor(writer http.ResponseWriter, request *http.Request, id int, version int, act *ActorRequest) {
+	actor := &domain.Actor{
+		Id:        id,
+		Name:      act.Name,
+		Gender:    genderStringToInt(act.Gender),
+		BirthDate: act.BirthDate,
+		Version:   version,
+	}
 	if err := h.act.UpdateActor(request.Context(), actor); err != nil {
 		h.HandleServiceError(writer, err)
 		return
 	}
 
+	setETag(writer, actor.Version)
 	writer.WriteHeader(http.StatusNoContent)
 }
 
@@ -108,6 +208,11 @@ func (h *Handler) GetActorHandler(writer http.ResponseWriter, request *http.Requ
 		return
 	}
 
+	if notModified(writer, request, versionTag(actor.Version), actor.UpdatedAt) {
+		writer.WriteHeader(http.StatusNotModified)
+		return
+	}
+
 	dto := actorToDTO(actor)
 
 	writer.WriteHeader(http.StatusOK)
@@ -118,8 +223,35 @@ func (h *Handler) GetActorHandler(writer http.ResponseWriter, request *http.Requ
 	}
 }
 
+// GetAllActorsHandler used to get actors page by page with searching by name, filtering and sorting,
+// next page is available by the returned cursor. Movies of the actors are included with include=movies
 func (h *Handler) GetAllActorsHandler(writer http.ResponseWriter, request *http.Request) {
-	actors, err := h.act.ListActors(request.Context())
+	sort, filter, err := buildActorSortingAndFilter(request.URL.Query())
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid query parameters: " + err.Error()))
+		return
+	}
+	page, err := parsePage(request.URL.Query())
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid limit"))
+		return
+	}
+	withMovies := false
+	for _, include := range strings.Split(request.URL.Query().Get("include"), ",") {
+		switch include {
+		case "movies":
+			withMovies = true
+		case "":
+		default:
+			writer.WriteHeader(http.StatusBadRequest)
+			_, _ = writer.Write([]byte("Invalid include"))
+			return
+		}
+	}
+
+	actors, next, err := h.act.ListActors(request.Context(), filter, sort, page, withMovies)
 	if err != nil {
 		h.HandleServiceError(writer, err)
 		return
@@ -135,8 +267,146 @@ func (h *Handler) GetAllActorsHandler(writer http.ResponseWriter, request *http.
 		return
 	}
 
+	setNextLink(writer, request, next)
 	writer.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(writer).Encode(dtos); err != nil {
+	if err := json.NewEncoder(writer).Encode(ActorListDTO{Actors: dtos, NextCursor: next}); err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		_, _ = writer.Write([]byte("Internal server error"))
+		return
+	}
+}
+
+// GetActorMoviesHandler used to get the filmography of an actor, it is sorted and paginated the same way as movies
+func (h *Handler) GetActorMoviesHandler(writer http.ResponseWriter, request *http.Request) {
+	id, err := strconv.Atoi(request.PathValue("id"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid actor id"))
+		return
+	}
+	sort, filter, err := buildSortingAndFilter(request.URL.Query())
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid query parameters: " + err.Error()))
+		return
+	}
+	page, err := parsePage(request.URL.Query())
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid limit"))
+		return
+	}
+
+	movies, next, err := h.mov.ListMovies(request.Context(), filter.WithActor(id), sort, page)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	dtos := make([]MovieDTO, 0, len(movies))
+	for _, m := range movies {
+		dtos = append(dtos, movieToDTO(m))
+	}
+	if len(dtos) == 0 {
+		writer.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if err := h.markWatchlist(request, dtos); err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	setNextLink(writer, request, next)
+	writer.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(writer).Encode(MovieListDTO{Movies: dtos, NextCursor: next}); err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		_, _ = writer.Write([]byte("Internal server error"))
+		return
+	}
+}
+
+// GetCostarsHandler used to get actors who played with the given one, the most frequent costars go first
+func (h *Handler) GetCostarsHandler(writer http.ResponseWriter, request *http.Request) {
+	id, err := strconv.Atoi(request.PathValue("id"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid actor id"))
+		return
+	}
+	page, err := parsePage(request.URL.Query())
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid limit"))
+		return
+	}
+
+	costars, next, err := h.act.ListCostars(request.Context(), id, page)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+	if len(costars) == 0 {
+		writer.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	dtos := make([]CostarDTO, 0, len(costars))
+	for _, c := range costars {
+		dtos = append(dtos, CostarDTO{ActorDTO: actorToDTO(c.Actor), SharedMovies: c.SharedMovies})
+	}
+
+	setNextLink(writer, request, next)
+	writer.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(writer).Encode(CostarListDTO{Costars: dtos, NextCursor: next}); err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		_, _ = writer.Write([]byte("Internal server error"))
+		return
+	}
+}
+
+// GetActorPathHandler used to find the shortest chain of costars between two actors, max_depth limits the number of movies
+func (h *Handler) GetActorPathHandler(writer http.ResponseWriter, request *http.Request) {
+	from, err := strconv.Atoi(request.PathValue("a"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid actor id"))
+		return
+	}
+	to, err := strconv.Atoi(request.PathValue("b"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid actor id"))
+		return
+	}
+	depth := 0
+	if v := request.URL.Query().Get("max_depth"); v != "" {
+		if depth, err = strconv.Atoi(v); err != nil || depth <= 0 {
+			writer.WriteHeader(http.StatusBadRequest)
+			_, _ = writer.Write([]byte("Invalid max_depth"))
+			return
+		}
+	}
+
+	path, err := h.act.ShortestPath(request.Context(), from, to, depth)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	dto := ActorPathDTO{
+		Degrees: len(path.Movies),
+		Actors:  make([]ActorDTO, 0, len(path.Actors)),
+		Movies:  make([]PathMovieDTO, 0, len(path.Movies)),
+	}
+	for _, a := range path.Actors {
+		dto.Actors = append(dto.Actors, actorToDTO(a))
+	}
+	for _, m := range path.Movies {
+		dto.Movies = append(dto.Movies, PathMovieDTO{Id: m.Id, Title: m.Title, ReleaseDate: m.ReleaseDate})
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(writer).Encode(dto); err != nil {
 		writer.WriteHeader(http.StatusInternalServerError)
 		_, _ = writer.Write([]byte("Internal server error"))
 		return
@@ -157,7 +427,13 @@ func (h *Handler) DeleteActorHandler(writer http.ResponseWriter, request *http.R
 		return
 	}
 
-	if err := h.act.DeleteActor(request.Context(), id); err != nil {
+	version, err := parseIfMatch(request, h.actorVersion, id)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	if err := h.act.DeleteActor(request.Context(), id, version); err != nil {
 		h.HandleServiceError(writer, err)
 		return
 	}
@@ -166,25 +442,108 @@ func (h *Handler) DeleteActorHandler(writer http.ResponseWriter, request *http.R
 
 }
 
+var actorSortFields = map[string]actor.SortBy{
+	"name":        actor.SortByName,
+	"birth_date":  actor.SortByBirthDate,
+	"movie_count": actor.SortByMovieCount,
+}
+
+// buildActorSortingAndFilter gets sorting and filter parameters from request and returns them as actor.Sorting and *actor.Filter
+func buildActorSortingAndFilter(u url.Values) (actor.Sorting, *actor.Filter, error) {
+	params, err := parseSort(u)
+	if err != nil {
+		return nil, nil, err
+	}
+	var sort actor.Sorting
+	for _, param := range params {
+		field, ok := actorSortFields[param.field]
+		if param.field == "" {
+			field, ok = actor.SortById, true
+		}
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown sort field %q", param.field)
+		}
+		key := actor.NewSortKey(field)
+		if param.desc != nil {
+			key.Desc = *param.desc
+		}
+		sort = append(sort, key)
+	}
+
+	filter := actor.NewFilter()
+	if name := u.Get("name"); name != "" {
+		filter = filter.WithName(name)
+	}
+	if gender := u.Get("gender"); gender != "" {
+		g := genderStringToInt(gender)
+		if g < 0 {
+			return nil, nil, errors.New("gender must be one of 'unknown', 'male', 'female' or 'not applicable'")
+		}
+		filter = filter.WithGender(g)
+	}
+	for _, param := range []string{"birth_date_from", "birth_date_to"} {
+		value := u.Get(param)
+		if value == "" {
+			continue
+		}
+		parsedDate, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s must be a date in YYYY-MM-DD format", param)
+		}
+		if param == "birth_date_to" {
+			filter = filter.WithBirthDateTo(parsedDate)
+		} else {
+			filter = filter.WithBirthDateFrom(parsedDate)
+		}
+	}
+
+	return sort, filter, nil
+}
+
 func actorToDTO(actor *domain.Actor) ActorDTO {
 	a := ActorDTO{
-		Id:        actor.Id,
-		Name:      actor.Name,
-		BirthDate: actor.BirthDate,
+		Id:         actor.Id,
+		Name:       actor.Name,
+		Gender:     genderIntToString(actor.Gender),
+		BirthDate:  actor.BirthDate,
+		MovieCount: actor.MovieCount,
+		DeletedAt:  actor.DeletedAt,
+		CreatedAt:  timestamp(actor.CreatedAt),
+		UpdatedAt:  timestamp(actor.UpdatedAt),
+	}
+	for _, role := range actor.Movies {
+		a.Movies = append(a.Movies, RoleDTO{
+			Id:          role.Id,
+			Title:       role.Title,
+			ReleaseDate: role.ReleaseDate,
+			Rating:      role.Rating,
+			Score:       role.Score,
+			Character:   role.Character,
+			Billing:     role.Billing,
+		})
 	}
-	switch actor.Gender {
+
+	return a
+}
+
+// actorToRequest is the actor as admins send it, patches are applied to it
+func actorToRequest(actor *domain.Actor) ActorRequest {
+	return ActorRequest{Name: actor.Name, Gender: genderIntToString(actor.Gender), BirthDate: actor.BirthDate}
+}
+
+func genderIntToString(g int) string {
+	switch g {
 	case 0:
-		a.Gender = "unknown"
+		return "unknown"
 	case 1:
-		a.Gender = "male"
+		return "male"
 	case 2:
-		a.Gender = "female"
-	default:
-		a.Gender = "not applicable"
+		return "female"
 	}
 
-	return a
+	return "not applicable"
 }
+
 func genderStringToInt(g string) int {
 	switch g {
 	case "unknown":
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+	"vk-backend/internal/domain"
+)
+
+type AuditEntryDTO struct {
+	Id       int             `json:"id"`
+	UserId   *int            `json:"user_id"` // null for changes made outside the API
+	Action   string          `json:"action"`
+	Entity   string          `json:"entity"`
+	EntityId int             `json:"entity_id"` // id of the movie for casts
+	Before   json.RawMessage `json:"before"`
+	After    json.RawMessage `json:"after"`
+	At       time.Time       `json:"at"`
+}
+
+type AuditListDTO struct {
+	Entries    []AuditEntryDTO `json:"entries"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+// GetAuditLogHandler used to list admin changes of actors, movies and casts, the latest first (admin only).
+// The log is filtered by user_id, entity (actor, movie, cast) with entity_id and from/to time range
+func (h *Handler) GetAuditLogHandler(writer http.ResponseWriter, request *http.Request) {
+	if !isAdminRole(request) {
+		h.HandleServiceError(writer, domain.ErrNotAdmin)
+		return
+	}
+	filter, err := buildAuditFilter(request.URL.Query())
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid query parameters: " + err.Error()))
+		return
+	}
+	page, err := parsePage(request.URL.Query())
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid limit"))
+		return
+	}
+
+	entries, next, err := h.aud.ListAuditLog(request.Context(), filter, page)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+	if len(entries) == 0 {
+		writer.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	dtos := make([]AuditEntryDTO, 0, len(entries))
+	for _, e := range entries {
+		dtos = append(dtos, AuditEntryDTO{
+			Id:       e.Id,
+			UserId:   e.UserId,
+			Action:   string(e.Action),
+			Entity:   string(e.Entity),
+			EntityId: e.EntityId,
+			Before:   e.Before,
+			After:    e.After,
+			At:       e.At,
+		})
+	}
+
+	setNextLink(writer, request, next)
+	writer.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(writer).Encode(AuditListDTO{Entries: dtos, NextCursor: next}); err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		_, _ = writer.Write([]byte("Internal server error"))
+		return
+	}
+}
+
+func buildAuditFilter(u url.Values) (domain.AuditFilter, error) {
+	var filter domain.AuditFilter
+	for _, param := range []string{"user_id", "entity_id"} {
+		value := u.Get(param)
+		if value == "" {
+			continue
+		}
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return filter, fmt.Errorf("%s must be an integer", param)
+		}
+		if param == "user_id" {
+			filter.UserId = &id
+		} else {
+			filter.EntityId = &id
+		}
+	}
+	if value := u.Get("entity"); value != "" {
+		entity := domain.AuditEntity(value)
+		filter.Entity = &entity
+	}
+	// bounds are either points in time or whole days
+	for _, param := range []string{"from", "to"} {
+		value := u.Get(param)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			t, err = time.Parse(time.DateOnly, value)
+			if err != nil {
+				return filter, fmt.Errorf("%s must be a time in RFC 3339 or a date in YYYY-MM-DD format", param)
+			}
+			if param == "to" {
+				t = t.AddDate(0, 0, 1)
+			}
+		}
+		if param == "from" {
+			filter.From = &t
+		} else {
+			filter.To = &t
+		}
+	}
+
+	return filter, nil
+}
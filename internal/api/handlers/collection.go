@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"vk-backend/internal/domain"
+)
+
+type CollectionRequest struct {
+	MovieId int `json:"movie_id"`
+}
+
+// GetCollectionHandler used to get movies of the watchlist or favorites of the current user,
+// they are filtered, sorted and paginated the same way as all the movies and sorted by the time added by default
+func (h *Handler) GetCollectionHandler(writer http.ResponseWriter, request *http.Request) {
+	c, ok := userCollection(writer, request)
+	if !ok {
+		return
+	}
+	sort, filter, err := buildSortingAndFilter(request.URL.Query())
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid query parameters: " + err.Error()))
+		return
+	}
+	page, err := parsePage(request.URL.Query())
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid limit"))
+		return
+	}
+
+	movies, next, err := h.mov.ListMovies(request.Context(), filter.WithCollection(c), sort, page)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	dtos := make([]MovieDTO, 0, len(movies))
+	for _, m := range movies {
+		dtos = append(dtos, movieToDTO(m))
+	}
+	if len(dtos) == 0 {
+		writer.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if err := h.markWatchlist(request, dtos); err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	setNextLink(writer, request, next)
+	writer.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(writer).Encode(MovieListDTO{Movies: dtos, NextCursor: next}); err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		_, _ = writer.Write([]byte("Internal server error"))
+		return
+	}
+}
+
+func (h *Handler) AddToCollectionHandler(writer http.ResponseWriter, request *http.Request) {
+	req := &CollectionRequest{}
+	if err := json.NewDecoder(request.Body).Decode(req); err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid request body"))
+		return
+	}
+
+	c, ok := userCollection(writer, request)
+	if !ok {
+		return
+	}
+
+	if err := h.col.AddMovie(request.Context(), c, req.MovieId); err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) RemoveFromCollectionHandler(writer http.ResponseWriter, request *http.Request) {
+	c, ok := userCollection(writer, request)
+	if !ok {
+		return
+	}
+
+	movieId, err := strconv.Atoi(request.PathValue("movieId"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid movie id"))
+		return
+	}
+
+	if err := h.col.RemoveMovie(request.Context(), c, movieId); err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// userCollection gets the collection named in the path of the current user, the error is written when there is none
+func userCollection(writer http.ResponseWriter, request *http.Request) (domain.UserCollection, bool) {
+	userId, ok := currentUserId(request)
+	if !ok {
+		writer.WriteHeader(http.StatusUnauthorized)
+		_, _ = writer.Write([]byte("Not authorized"))
+		return domain.UserCollection{}, false
+	}
+	c := domain.Collection(request.PathValue("collection"))
+	if !c.Valid() {
+		writer.WriteHeader(http.StatusNotFound)
+		_, _ = writer.Write([]byte("Collection does not exist"))
+		return domain.UserCollection{}, false
+	}
+
+	return domain.UserCollection{UserId: userId, Collection: c}, true
+}
+
+// markWatchlist flags which of the movies are in the watchlist of the current user, when the user is known
+func (h *Handler) markWatchlist(request *http.Request, dtos []MovieDTO) error {
+	userId, ok := currentUserId(request)
+	if !ok || len(dtos) == 0 {
+		return nil
+	}
+
+	ids := make([]int, 0, len(dtos))
+	for _, dto := range dtos {
+		ids = append(ids, dto.Id)
+	}
+	in, err := h.col.Contains(request.Context(), domain.UserCollection{UserId: userId, Collection: domain.Watchlist}, ids)
+	if err != nil {
+		return err
+	}
+	for i := range dtos {
+		inWatchlist := in[dtos[i].Id]
+		dtos[i].InWatchlist = &inWatchlist
+	}
+
+	return nil
+}
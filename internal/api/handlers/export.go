@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"vk-backend/internal/domain"
+)
+
+// exportEncoder writes records of the export one by one
+type exportEncoder[T any] interface {
+	begin() error
+	encode(record T) error
+	end() error
+}
+
+// exportEntity tells how records of an exported entity are written in every format
+type exportEntity[T any] struct {
+	name   string // base name of the exported file
+	dto    func(T) any
+	header []string // columns of the csv
+	row    func(T) []string
+}
+
+type ndjsonEncoder[T any] struct {
+	enc *json.Encoder
+	dto func(T) any
+}
+
+func (e *ndjsonEncoder[T]) begin() error { return nil }
+
+func (e *ndjsonEncoder[T]) encode(record T) error { return e.enc.Encode(e.dto(record)) }
+
+func (e *ndjsonEncoder[T]) end() error { return nil }
+
+// jsonEncoder writes an array without holding it in memory
+type jsonEncoder[T any] struct {
+	w     io.Writer
+	dto   func(T) any
+	count int
+}
+
+func (e *jsonEncoder[T]) begin() error {
+	_, err := io.WriteString(e.w, "[")
+	return err
+}
+
+func (e *jsonEncoder[T]) encode(record T) error {
+	data, err := json.Marshal(e.dto(record))
+	if err != nil {
+		return err
+	}
+	if e.count > 0 {
+		if _, err := io.WriteString(e.w, ","); err != nil {
+			return err
+		}
+	}
+	e.count++
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *jsonEncoder[T]) end() error {
+	_, err := io.WriteString(e.w, "]\n")
+	return err
+}
+
+type csvEncoder[T any] struct {
+	w      *csv.Writer
+	header []string
+	row    func(T) []string
+}
+
+func (e *csvEncoder[T]) begin() error { return e.w.Write(e.header) }
+
+func (e *csvEncoder[T]) encode(record T) error { return e.w.Write(e.row(record)) }
+
+func (e *csvEncoder[T]) end() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// movieExport writes a movie per csv row with the columns id, title, description, release_date, rating, score,
+// review_count, genres and cast. Genres are listed by name and cast members as name|birth_date in the order
+// of billing, both separated by semicolons. It's a report of the catalog rather than a file for the import,
+// which takes a type column and has no ids, scores or genres.
+var movieExport = exportEntity[*domain.Movie]{
+	name:   "movies",
+	dto:    func(m *domain.Movie) any { return movieToDTO(m) },
+	header: []string{"id", "title", "description", "release_date", "rating", "score", "review_count", "genres", "cast"},
+	row: func(m *domain.Movie) []string {
+		genres := make([]string, 0, len(m.Genres))
+		for _, g := range m.Genres {
+			genres = append(genres, g.Name)
+		}
+		cast := make([]string, 0, len(m.Actors))
+		for _, a := range m.Actors {
+			cast = append(cast, a.Name+"|"+a.BirthDate.Format(time.DateOnly))
+		}
+
+		return []string{
+			strconv.Itoa(m.Id),
+			m.Title,
+			m.Description,
+			m.ReleaseDate.Format(time.DateOnly),
+			strconv.FormatFloat(m.Rating, 'f', 1, 64),
+			strconv.FormatFloat(m.Score, 'f', 1, 64),
+			strconv.Itoa(m.ReviewCount),
+			strings.Join(genres, ";"),
+			strings.Join(cast, ";"),
+		}
+	},
+}
+
+// actorExport writes an actor per csv row with the columns id, name, gender and birth_date
+var actorExport = exportEntity[*domain.Actor]{
+	name:   "actors",
+	dto:    func(a *domain.Actor) any { return actorToDTO(a) },
+	header: []string{"id", "name", "gender", "birth_date"},
+	row: func(a *domain.Actor) []string {
+		return []string{strconv.Itoa(a.Id), a.Name, genderIntToString(a.Gender), a.BirthDate.Format(time.DateOnly)}
+	},
+}
+
+// ExportHandler used to stream the whole catalog as ndjson (default), json or csv. The entity parameter chooses
+// what is exported: movies (default) with casts and genres, or actors, including the ones that aren't in any cast.
+// Records are written as they are read from the database, so the response starts before the export is over.
+func (h *Handler) ExportHandler(writer http.ResponseWriter, request *http.Request) {
+	if !isAdminRole(request) {
+		h.HandleServiceError(writer, domain.ErrNotAdmin)
+		return
+	}
+
+	switch request.URL.Query().Get("entity") {
+	case "movies", "":
+		export(h, writer, request, movieExport, h.mov.ExportMovies)
+	case "actors":
+		export(h, writer, request, actorExport, h.act.ExportActors)
+	default:
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid entity"))
+	}
+}
+
+// export streams records the run passes in the format of the request
+func export[T any](h *Handler, writer http.ResponseWriter, request *http.Request, entity exportEntity[T], run func(ctx context.Context, fn func(T) error) error) {
+	var enc exportEncoder[T]
+	var contentType string
+	format := request.URL.Query().Get("format")
+	switch format {
+	case "ndjson", "":
+		format, contentType = "ndjson", "application/x-ndjson"
+		enc = &ndjsonEncoder[T]{enc: json.NewEncoder(writer), dto: entity.dto}
+	case "json":
+		contentType = "application/json"
+		enc = &jsonEncoder[T]{w: writer, dto: entity.dto}
+	case "csv":
+		contentType = "text/csv"
+		enc = &csvEncoder[T]{w: csv.NewWriter(writer), header: entity.header, row: entity.row}
+	default:
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid format"))
+		return
+	}
+
+	started := false
+	start := func() error {
+		writer.Header().Set("Content-Type", contentType)
+		writer.Header().Set("Content-Disposition", `attachment; filename="`+entity.name+`.`+format+`"`)
+		writer.WriteHeader(http.StatusOK)
+		started = true
+		return enc.begin()
+	}
+
+	err := run(request.Context(), func(record T) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		return enc.encode(record)
+	})
+	if err == nil && !started {
+		err = start()
+	}
+	if err == nil {
+		err = enc.end()
+	}
+	switch {
+	case err != nil && !started:
+		h.HandleServiceError(writer, err)
+	case err != nil:
+		// the status is already sent, breaking the connection tells the client the export is incomplete
+		panic(http.ErrAbortHandler)
+	}
+}
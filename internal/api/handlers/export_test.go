@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"vk-backend/internal/domain"
+	"vk-backend/internal/service/actor"
+	"vk-backend/mocks"
+)
+
+func TestExportHandler_Actors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockActorRepository(ctrl)
+	h := &Handler{act: actor.NewService(repo)}
+
+	repo.
+		EXPECT().
+		ExportActors(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, fn func(*domain.Actor) error) error {
+			return fn(&domain.Actor{Id: 1, Name: "Keanu Reeves", Gender: 1, BirthDate: time.Date(1964, 9, 2, 0, 0, 0, 0, time.UTC)})
+		})
+
+	request := httptest.NewRequest(http.MethodGet, "/admin/export?entity=actors&format=csv", nil)
+	request = request.WithContext(context.WithValue(request.Context(), "user_role", "admin"))
+	recorder := httptest.NewRecorder()
+	h.ExportHandler(recorder, request)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, `attachment; filename="actors.csv"`, recorder.Header().Get("Content-Disposition"))
+	assert.Equal(t, "id,name,gender,birth_date\n1,Keanu Reeves,male,1964-09-02\n", recorder.Body.String())
+
+	request = httptest.NewRequest(http.MethodGet, "/admin/export?entity=genres", nil)
+	request = request.WithContext(context.WithValue(request.Context(), "user_role", "admin"))
+	recorder = httptest.NewRecorder()
+	h.ExportHandler(recorder, request)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"vk-backend/internal/domain"
+)
+
+type GenreRequest struct {
+	Name string `json:"name"`
+}
+
+type GenreDTO struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func (h *Handler) AddGenreHandler(writer http.ResponseWriter, request *http.Request) {
+	req := &GenreRequest{}
+	if err := json.NewDecoder(request.Body).Decode(req); err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid request body"))
+		return
+	}
+
+	if !isAdminRole(request) {
+		h.HandleServiceError(writer, domain.ErrNotAdmin)
+		return
+	}
+
+	genre, err := h.gen.AddGenre(request.Context(), req.Name)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(writer).Encode(genreToDTO(genre)); err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		_, _ = writer.Write([]byte("Internal server error"))
+		return
+	}
+}
+
+func (h *Handler) UpdateGenreHandler(writer http.ResponseWriter, request *http.Request) {
+	req := &GenreRequest{}
+	if err := json.NewDecoder(request.Body).Decode(req); err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid request body"))
+		return
+	}
+
+	if !isAdminRole(request) {
+		h.HandleServiceError(writer, domain.ErrNotAdmin)
+		return
+	}
+
+	id, err := strconv.Atoi(request.PathValue("id"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid genre id"))
+		return
+	}
+
+	if err := h.gen.UpdateGenre(request.Context(), &domain.Genre{Id: id, Name: req.Name}); err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) GetGenreHandler(writer http.ResponseWriter, request *http.Request) {
+	id, err := strconv.Atoi(request.PathValue("id"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid genre id"))
+		return
+	}
+
+	genre, err := h.gen.GetGenreById(request.Context(), id)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(writer).Encode(genreToDTO(genre)); err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		_, _ = writer.Write([]byte("Internal server error"))
+		return
+	}
+}
+
+func (h *Handler) GetAllGenresHandler(writer http.ResponseWriter, request *http.Request) {
+	genres, err := h.gen.ListGenres(request.Context())
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	if len(genres) == 0 {
+		writer.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	dtos := make([]GenreDTO, 0, len(genres))
+	for _, g := range genres {
+		dtos = append(dtos, genreToDTO(g))
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(writer).Encode(dtos); err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		_, _ = writer.Write([]byte("Internal server error"))
+		return
+	}
+}
+
+func (h *Handler) DeleteGenreHandler(writer http.ResponseWriter, request *http.Request) {
+	if !isAdminRole(request) {
+		h.HandleServiceError(writer, domain.ErrNotAdmin)
+		return
+	}
+
+	id, err := strconv.Atoi(request.PathValue("id"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid genre id"))
+		return
+	}
+
+	if err := h.gen.DeleteGenre(request.Context(), id); err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func genreToDTO(genre *domain.Genre) GenreDTO {
+	return GenreDTO{
+		Id:   genre.Id,
+		Name: genre.Name,
+	}
+}
@@ -1,24 +1,61 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"hash/fnv"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 	"vk-backend/internal/domain"
 	"vk-backend/internal/service/actor"
+	"vk-backend/internal/service/audit"
+	"vk-backend/internal/service/collection"
+	"vk-backend/internal/service/genre"
+	"vk-backend/internal/service/importer"
 	"vk-backend/internal/service/movie"
+	"vk-backend/internal/service/review"
+	"vk-backend/internal/service/stats"
 	"vk-backend/internal/service/user"
 )
 
 type Handler struct {
 	act  actor.ActorService
 	mov  movie.MovieService
+	gen  genre.GenreService
+	rev  review.ReviewService
+	col  collection.CollectionService
+	stat stats.StatsService
+	imp  importer.ImportService
+	aud  audit.AuditService
 	user user.UserService
 }
 
-func New(act actor.ActorService, mov movie.MovieService, user user.UserService) *Handler {
+func New(
+	act actor.ActorService,
+	mov movie.MovieService,
+	gen genre.GenreService,
+	rev review.ReviewService,
+	col collection.CollectionService,
+	stat stats.StatsService,
+	imp importer.ImportService,
+	aud audit.AuditService,
+	user user.UserService,
+) *Handler {
 	return &Handler{
 		act:  act,
 		mov:  mov,
+		gen:  gen,
+		rev:  rev,
+		col:  col,
+		stat: stat,
+		imp:  imp,
+		aud:  aud,
 		user: user,
 	}
 }
@@ -58,9 +95,24 @@ func (h *Handler) HandleServiceError(writer http.ResponseWriter, err error) {
 	case errors.Is(err, domain.ErrInvalidRating):
 		writer.WriteHeader(http.StatusBadRequest)
 		_, _ = writer.Write([]byte("Rating is invalid"))
+	case errors.Is(err, domain.ErrTooLongCharacter):
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Character name is too long"))
+	case errors.Is(err, domain.ErrInvalidBilling):
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Billing is invalid"))
+	case errors.Is(err, domain.ErrRepeatedCredit):
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Actor or billing is repeated in the cast"))
 	case errors.Is(err, domain.ErrActorAlreadyInMovie):
 		writer.WriteHeader(http.StatusConflict)
 		_, _ = writer.Write([]byte("Actor is already in the movie"))
+	case errors.Is(err, domain.ErrBillingTaken):
+		writer.WriteHeader(http.StatusConflict)
+		_, _ = writer.Write([]byte("Billing is taken by another actor"))
+	case errors.Is(err, domain.ErrActorNotInMovie):
+		writer.WriteHeader(http.StatusNotFound)
+		_, _ = writer.Write([]byte("Actor is not in the movie"))
 	case errors.Is(err, domain.ErrEmptyReleaseDate):
 		writer.WriteHeader(http.StatusBadRequest)
 		_, _ = writer.Write([]byte("Release date cannot be empty"))
@@ -79,8 +131,280 @@ func (h *Handler) HandleServiceError(writer http.ResponseWriter, err error) {
 	case errors.Is(err, domain.ErrNotAdmin):
 		writer.WriteHeader(http.StatusForbidden)
 		_, _ = writer.Write([]byte("not allowed"))
+	case errors.Is(err, domain.ErrGenreNotExists):
+		writer.WriteHeader(http.StatusNotFound)
+		_, _ = writer.Write([]byte("Genre does not exist"))
+	case errors.Is(err, domain.ErrGenreAlreadyExists):
+		writer.WriteHeader(http.StatusConflict)
+		_, _ = writer.Write([]byte("Genre already exists"))
+	case errors.Is(err, domain.ErrRepeatedGenre):
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Genre is repeated"))
+	case errors.Is(err, domain.ErrTooLongName):
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Name is too long"))
+	case errors.Is(err, domain.ErrInvalidSort):
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid sort. Fields cannot repeat, relevance requires a search query"))
+	case errors.Is(err, domain.ErrInvalidDateRange):
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Date range is invalid"))
+	case errors.Is(err, domain.ErrInvalidRatingRange):
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Rating range is invalid"))
+	case errors.Is(err, domain.ErrRevisionNotExists):
+		writer.WriteHeader(http.StatusNotFound)
+		_, _ = writer.Write([]byte("Revision does not exist"))
+	case errors.Is(err, domain.ErrReviewNotExists):
+		writer.WriteHeader(http.StatusNotFound)
+		_, _ = writer.Write([]byte("Review does not exist"))
+	case errors.Is(err, domain.ErrTooLongReview):
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Review is too long"))
+	case errors.Is(err, domain.ErrNotAuthorized):
+		writer.WriteHeader(http.StatusUnauthorized)
+		_, _ = writer.Write([]byte("Not authorized"))
+	case errors.Is(err, domain.ErrCollectionNotExists):
+		writer.WriteHeader(http.StatusNotFound)
+		_, _ = writer.Write([]byte("Collection does not exist"))
+	case errors.Is(err, domain.ErrMovieNotInCollection):
+		writer.WriteHeader(http.StatusNotFound)
+		_, _ = writer.Write([]byte("Movie is not in the collection"))
+	case errors.Is(err, domain.ErrPathNotFound):
+		writer.WriteHeader(http.StatusNotFound)
+		_, _ = writer.Write([]byte("Actors are not connected"))
+	case errors.Is(err, domain.ErrInvalidDepth):
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Depth is invalid"))
+	case errors.Is(err, domain.ErrInvalidBuckets):
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Number of buckets is invalid"))
+	case errors.Is(err, domain.ErrInvalidImportFormat):
+		writer.WriteHeader(http.StatusUnsupportedMediaType)
+		_, _ = writer.Write([]byte("Import format is not supported"))
+	case errors.Is(err, domain.ErrTooManyImportRows):
+		writer.WriteHeader(http.StatusRequestEntityTooLarge)
+		_, _ = writer.Write([]byte("Too many rows to import"))
+	case errors.Is(err, domain.ErrNotInTrash):
+		writer.WriteHeader(http.StatusNotFound)
+		_, _ = writer.Write([]byte("Record is not in the trash"))
+	case errors.Is(err, domain.ErrInvalidAuditFilter):
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid audit log filter. Entity can be 'actor', 'movie' or 'cast', entity_id requires entity, from must be before to"))
+	case errors.Is(err, domain.ErrVersionRequired):
+		writer.WriteHeader(http.StatusPreconditionRequired)
+		_, _ = writer.Write([]byte("If-Match header with the ETag of the record is required"))
+	case errors.Is(err, domain.ErrVersionMismatch):
+		writer.WriteHeader(http.StatusPreconditionFailed)
+		_, _ = writer.Write([]byte("Record was changed, get its latest version"))
+	case errors.Is(err, domain.ErrInvalidCursor):
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid cursor"))
 	default:
 		writer.WriteHeader(http.StatusInternalServerError)
 		_, _ = writer.Write([]byte("Internal server error"))
 	}
 }
+
+// parsePage gets limit and cursor of the requested page, limit above the maximum is capped by services
+func parsePage(u url.Values) (domain.Page, error) {
+	page := domain.Page{Cursor: u.Get("cursor")}
+	if limit := u.Get("limit"); limit != "" {
+		parsedLimit, err := strconv.Atoi(limit)
+		if err != nil || parsedLimit <= 0 {
+			return domain.Page{}, errors.New("invalid limit")
+		}
+		page.Limit = parsedLimit
+	}
+
+	return page, nil
+}
+
+// setNextLink advertises the next page in the Link header (RFC 8288), empty cursor means there is no next page
+func setNextLink(writer http.ResponseWriter, request *http.Request, next string) {
+	if next == "" {
+		return
+	}
+	u := *request.URL
+	q := u.Query()
+	q.Set("cursor", next)
+	u.RawQuery = q.Encode()
+	writer.Header().Set("Link", "<"+u.RequestURI()+`>; rel="next"`)
+}
+
+// versionTag is the entity tag of the version of a record
+func versionTag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// setETag exposes the version of the record, that must be given back in If-Match to change the record
+func setETag(writer http.ResponseWriter, version int) {
+	writer.Header().Set("ETag", versionTag(version))
+}
+
+// representationTag is the entity tag of the record as it's shown. It starts with the version, so it's taken
+// by If-Match, and tells apart what is shown along with the record without changing its version,
+// like the score, names of the cast or the watchlist of the user.
+func representationTag(version int, dto any) (string, error) {
+	data, err := json.Marshal(dto)
+	if err != nil {
+		return "", err
+	}
+	hash := fnv.New64a()
+	_, _ = hash.Write(data)
+
+	return fmt.Sprintf(`"%d-%x"`, version, hash.Sum64()), nil
+}
+
+// parseIfMatch returns the version of the record the client has seen (RFC 9110). A single tag is taken as is,
+// a list of tags matches if any of them is the current version and "*" matches any current version,
+// both are checked against the version of the record with the id given by current.
+// Weak and malformed tags can't match any version.
+func parseIfMatch(request *http.Request, current func(ctx context.Context, id int) (int, error), id int) (int, error) {
+	value := request.Header.Get("If-Match")
+	if strings.TrimSpace(value) == "" {
+		return 0, domain.ErrVersionRequired
+	}
+
+	var versions []int
+	for _, tag := range strings.Split(value, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return current(request.Context(), id)
+		}
+		if version, ok := tagVersion(tag); ok {
+			versions = append(versions, version)
+		}
+	}
+	switch len(versions) {
+	case 0:
+		return 0, domain.ErrVersionMismatch
+	case 1:
+		return versions[0], nil
+	}
+
+	version, err := current(request.Context(), id)
+	if err != nil {
+		return 0, err
+	}
+	if !slices.Contains(versions, version) {
+		return 0, domain.ErrVersionMismatch
+	}
+
+	return version, nil
+}
+
+// tagVersion returns the version of a strong entity tag, representation tags are the version
+// followed by the hash of what is shown
+func tagVersion(tag string) (int, bool) {
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, false
+	}
+	number, _, _ := strings.Cut(tag[1:len(tag)-1], "-")
+	version, err := strconv.Atoi(number)
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+
+	return version, true
+}
+
+// movieVersion is the current version of the movie for If-Match
+func (h *Handler) movieVersion(ctx context.Context, id int) (int, error) {
+	movie, err := h.mov.GetMovieById(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	return movie.Version, nil
+}
+
+// actorVersion is the current version of the actor for If-Match
+func (h *Handler) actorVersion(ctx context.Context, id int) (int, error) {
+	actor, err := h.act.GetActorById(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	return actor.Version, nil
+}
+
+// timestamp omits times the repository didn't select
+func timestamp(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// notModified sets the validators of the record and tells whether the client has its current state (RFC 9110).
+// If-None-Match takes precedence over If-Modified-Since, which is precise to a second.
+// A zero lastModified leaves out Last-Modified, when the time doesn't tell every change of the representation.
+func notModified(writer http.ResponseWriter, request *http.Request, etag string, lastModified time.Time) bool {
+	writer.Header().Set("ETag", etag)
+	if !lastModified.IsZero() {
+		writer.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if value := request.Header.Get("If-None-Match"); value != "" {
+		for _, tag := range strings.Split(value, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if value := request.Header.Get("If-Modified-Since"); value != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(value)
+		return err == nil && !lastModified.Truncate(time.Second).After(since)
+	}
+
+	return false
+}
+
+type sortParam struct {
+	field string // empty for the default field of the listing
+	desc  *bool  // nil keeps the default direction of the field
+}
+
+// parseSort reads the sort parameter, a comma separated list of fields, each may be prefixed with '-' for descending
+// or '+' for ascending order. Fields without prefix are sorted in the direction given by the order parameter
+// or in their default direction. Without the sort parameter the order applies to the default field.
+// Unescaped '+' is decoded from the query as a space, so a leading space is taken as '+' as well.
+func parseSort(u url.Values) ([]sortParam, error) {
+	order := u.Get("order")
+	if order != "" && order != "asc" && order != "desc" {
+		return nil, errors.New("order must be 'asc' or 'desc'")
+	}
+
+	sort := u.Get("sort")
+	if sort == "" {
+		if order == "" {
+			return nil, nil
+		}
+		desc := order == "desc"
+		return []sortParam{{desc: &desc}}, nil
+	}
+
+	var params []sortParam
+	for _, name := range strings.Split(sort, ",") {
+		if strings.HasPrefix(name, " ") {
+			name = "+" + strings.TrimLeft(name, " ")
+		}
+		prefix := ""
+		if strings.HasPrefix(name, "-") || strings.HasPrefix(name, "+") {
+			prefix, name = name[:1], name[1:]
+		}
+		if name == "" {
+			return nil, errors.New("sort fields must not be empty")
+		}
+		param := sortParam{field: name}
+		if prefix != "" || order != "" {
+			desc := prefix == "-" || prefix == "" && order == "desc"
+			param.desc = &desc
+		}
+		params = append(params, param)
+	}
+
+	return params, nil
+}
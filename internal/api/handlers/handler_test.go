@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"testing"
+)
+
+func TestParseSort(t *testing.T) {
+	asc, desc := false, true
+	tests := []struct {
+		query  string
+		params []sortParam
+		err    bool
+	}{
+		{query: ""},
+		{query: "sort=title", params: []sortParam{{field: "title"}}},
+		{query: "sort=title,-rating", params: []sortParam{{field: "title"}, {field: "rating", desc: &desc}}},
+		{query: "sort=%2Brating", params: []sortParam{{field: "rating", desc: &asc}}},
+		// unescaped '+' is decoded as a space
+		{query: "sort=+rating", params: []sortParam{{field: "rating", desc: &asc}}},
+		{query: "sort=title,+rating&order=desc", params: []sortParam{{field: "title", desc: &desc}, {field: "rating", desc: &asc}}},
+		{query: "sort=-title&order=asc", params: []sortParam{{field: "title", desc: &desc}}},
+		// without sort the order applies to the default field
+		{query: "order=asc", params: []sortParam{{desc: &asc}}},
+		{query: "order=desc", params: []sortParam{{desc: &desc}}},
+		{query: "order=up", err: true},
+		{query: "sort=title,", err: true},
+		{query: "sort=-", err: true},
+	}
+
+	for _, test := range tests {
+		u, err := url.ParseQuery(test.query)
+		if !assert.NoError(t, err, test.query) {
+			continue
+		}
+		params, err := parseSort(u)
+		if test.err {
+			assert.Error(t, err, test.query)
+			continue
+		}
+		assert.NoError(t, err, test.query)
+		assert.Equal(t, test.params, params, test.query)
+	}
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+	"vk-backend/internal/domain"
+	"vk-backend/internal/service/importer"
+)
+
+const maxImportSize = 32 << 20
+
+type ImportErrorDTO struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+type ImportReportDTO struct {
+	DryRun bool             `json:"dry_run"`
+	Actors int              `json:"actors"`
+	Movies int              `json:"movies"`
+	Errors []ImportErrorDTO `json:"errors"`
+}
+
+// ImportHandler used to add actors and movies in bulk from CSV or NDJSON, the format is taken from the format
+// query parameter or the Content-Type. Invalid rows are skipped and reported, dry_run only validates the file.
+func (h *Handler) ImportHandler(writer http.ResponseWriter, request *http.Request) {
+	if !isAdminRole(request) {
+		h.HandleServiceError(writer, domain.ErrNotAdmin)
+		return
+	}
+	dryRun := false
+	if v := request.URL.Query().Get("dry_run"); v != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(v); err != nil {
+			writer.WriteHeader(http.StatusBadRequest)
+			_, _ = writer.Write([]byte("Invalid dry_run"))
+			return
+		}
+	}
+
+	body := http.MaxBytesReader(writer, request.Body, maxImportSize)
+	report, err := h.imp.Import(request.Context(), importFormat(request), body, dryRun)
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		writer.WriteHeader(http.StatusRequestEntityTooLarge)
+		_, _ = writer.Write([]byte("Import file is too large"))
+		return
+	case errors.Is(err, domain.ErrInvalidImportFile):
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte(err.Error()))
+		return
+	case err != nil:
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	dto := ImportReportDTO{
+		DryRun: report.DryRun,
+		Actors: report.Actors,
+		Movies: report.Movies,
+		Errors: make([]ImportErrorDTO, 0, len(report.Errors)),
+	}
+	for _, e := range report.Errors {
+		dto.Errors = append(dto.Errors, ImportErrorDTO{Line: e.Line, Error: e.Err.Error()})
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(writer).Encode(dto); err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		_, _ = writer.Write([]byte("Internal server error"))
+		return
+	}
+}
+
+func importFormat(request *http.Request) importer.Format {
+	if format := request.URL.Query().Get("format"); format != "" {
+		return importer.Format(format)
+	}
+	mediaType, _, _ := mime.ParseMediaType(request.Header.Get("Content-Type"))
+	switch mediaType {
+	case "text/csv":
+		return importer.FormatCSV
+	case "application/x-ndjson", "application/ndjson", "application/jsonl":
+		return importer.FormatNDJSON
+	}
+
+	return ""
+}
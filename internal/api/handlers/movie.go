@@ -2,30 +2,72 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 	"vk-backend/internal/domain"
 	"vk-backend/internal/service/movie"
 )
 
 type MovieRequest struct {
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	ReleaseDate time.Time `json:"release_date"`
-	Rating      float64   `json:"rating"`
-	Actors      []int     `json:"actors"` // actor ids
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	ReleaseDate time.Time     `json:"release_date"`
+	Rating      float64       `json:"rating"`
+	Actors      []CastRequest `json:"actors"`
+	Genres      []int         `json:"genres"` // genre ids
+}
+
+// CastRequest credits an actor in a movie, a plain actor id is accepted as well
+type CastRequest struct {
+	ActorId   int    `json:"actor_id"`
+	Character string `json:"character"`
+	Billing   int    `json:"billing"` // 0 bills the actor after the highest billing, in the order of the list
+}
+
+func (c *CastRequest) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &c.ActorId); err == nil {
+		return nil
+	}
+
+	type credit CastRequest
+	return json.Unmarshal(data, (*credit)(c))
 }
 
 type MovieDTO struct {
-	Id          int        `json:"id"`
-	Title       string     `json:"title"`
-	Description string     `json:"description"`
-	ReleaseDate time.Time  `json:"release_date"`
-	Rating      float64    `json:"rating"`
-	Actors      []ActorDTO `json:"actors"`
+	Id          int             `json:"id"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	ReleaseDate time.Time       `json:"release_date"`
+	Rating      float64         `json:"rating"` // set by admins
+	Score       float64         `json:"score"`  // average user rating, or rating until the movie is rated
+	ReviewCount int             `json:"review_count"`
+	Actors      []CastMemberDTO `json:"actors"` // ordered by billing
+	Genres      []GenreDTO      `json:"genres"`
+	Relevance   float64         `json:"relevance,omitempty"`
+	Highlight   string          `json:"highlight,omitempty"`    // HTML, the text is escaped and matches are in <b> tags
+	InWatchlist *bool           `json:"in_watchlist,omitempty"` // only for authenticated users
+	AddedAt     *time.Time      `json:"added_at,omitempty"`     // only in collections
+	Similarity  float64         `json:"similarity,omitempty"`   // only in recommendations
+	DeletedAt   *time.Time      `json:"deleted_at,omitempty"`   // only in the trash
+	CreatedAt   *time.Time      `json:"created_at,omitempty"`   // not in casts and recommendations
+	UpdatedAt   *time.Time      `json:"updated_at,omitempty"`
+}
+
+type CastMemberDTO struct {
+	ActorDTO
+	Character string `json:"character"`
+	Billing   int    `json:"billing"`
+}
+
+type MovieListDTO struct {
+	Movies      []MovieDTO `json:"movies"`
+	NextCursor  string     `json:"next_cursor,omitempty"`
+	Suggestions []string   `json:"suggestions,omitempty"` // "did you mean", when the search found nothing
 }
 
 func (h *Handler) AddMovieHandler(writer http.ResponseWriter, request *http.Request) {
@@ -41,17 +83,19 @@ func (h *Handler) AddMovieHandler(writer http.ResponseWriter, request *http.Requ
 		return
 	}
 
-	actors := make([]*domain.Actor, 0, len(mov.Actors))
-	for _, id := range mov.Actors {
-		actor, err := h.act.GetActorById(request.Context(), id)
-		if err != nil {
-			h.HandleServiceError(writer, err)
-			return
-		}
-		actors = append(actors, actor)
+	actors, err := h.getCast(request, mov.Actors)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
 	}
 
-	movie, err := h.mov.AddMovie(request.Context(), mov.Title, mov.Description, mov.ReleaseDate, mov.Rating, actors)
+	genres, err := h.getGenres(request, mov.Genres)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	movie, err := h.mov.AddMovie(request.Context(), mov.Title, mov.Description, mov.ReleaseDate, mov.Rating, actors, genres)
 	if err != nil {
 		h.HandleServiceError(writer, err)
 		return
@@ -68,7 +112,9 @@ func (h *Handler) AddMovieHandler(writer http.ResponseWriter, request *http.Requ
 }
 
 type AddActorToMovieRequest struct {
-	ActorId int `json:"actor_id"`
+	ActorId   int    `json:"actor_id"`
+	Character string `json:"character"`
+	Billing   int    `json:"billing"` // 0 puts the actor after the rest of the cast
 }
 
 func (h *Handler) AddActorToMovieHandler(writer http.ResponseWriter, request *http.Request) {
@@ -91,14 +137,114 @@ func (h *Handler) AddActorToMovieHandler(writer http.ResponseWriter, request *ht
 		return
 	}
 
-	err = h.mov.AddActorToMovie(request.Context(), req.ActorId, movieId)
+	version, err := parseIfMatch(request, h.movieVersion, movieId)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	version, err = h.mov.AddActorToMovie(request.Context(), req.ActorId, movieId, req.Character, req.Billing, version)
 	if err != nil {
 		h.HandleServiceError(writer, err)
 		return
 	}
 
+	setETag(writer, version)
 	writer.WriteHeader(http.StatusNoContent)
 }
+
+func (h *Handler) RemoveActorFromMovieHandler(writer http.ResponseWriter, request *http.Request) {
+	if !isAdminRole(request) {
+		h.HandleServiceError(writer, domain.ErrNotAdmin)
+		return
+	}
+
+	movieId, err := strconv.Atoi(request.PathValue("id"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid movie id"))
+		return
+	}
+	actorId, err := strconv.Atoi(request.PathValue("actorId"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid actor id"))
+		return
+	}
+
+	version, err := parseIfMatch(request, h.movieVersion, movieId)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	version, err = h.mov.RemoveActorFromMovie(request.Context(), actorId, movieId, version)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	setETag(writer, version)
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+type ReplaceCastRequest struct {
+	Actors []CastRequest `json:"actors"`
+}
+
+// ReplaceCastHandler replaces the whole cast of the movie, the response is the new cast
+func (h *Handler) ReplaceCastHandler(writer http.ResponseWriter, request *http.Request) {
+	req := &ReplaceCastRequest{}
+	if err := json.NewDecoder(request.Body).Decode(req); err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid request body"))
+		return
+	}
+
+	if !isAdminRole(request) {
+		h.HandleServiceError(writer, domain.ErrNotAdmin)
+		return
+	}
+
+	movieId, err := strconv.Atoi(request.PathValue("id"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid movie id"))
+		return
+	}
+
+	version, err := parseIfMatch(request, h.movieVersion, movieId)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	cast, err := h.getCast(request, req.Actors)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	version, err = h.mov.ReplaceCast(request.Context(), movieId, cast, version)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	movie, err := h.mov.GetMovieById(request.Context(), movieId)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	setETag(writer, version)
+	if err := json.NewEncoder(writer).Encode(movieToDTO(movie).Actors); err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		_, _ = writer.Write([]byte("Internal server error"))
+		return
+	}
+}
+
 func (h *Handler) GetMovieHandler(writer http.ResponseWriter, request *http.Request) {
 	id, err := strconv.Atoi(request.PathValue("id"))
 	if err != nil {
@@ -113,10 +259,32 @@ func (h *Handler) GetMovieHandler(writer http.ResponseWriter, request *http.Requ
 		return
 	}
 
-	dto := movieToDTO(movie)
+	dtos := []MovieDTO{movieToDTO(movie)}
+	if err := h.markWatchlist(request, dtos); err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	etag, err := representationTag(movie.Version, dtos[0])
+	if err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		_, _ = writer.Write([]byte("Internal server error"))
+		return
+	}
+	lastModified := movie.UpdatedAt
+	if dtos[0].InWatchlist != nil {
+		// the watchlist isn't dated, only the entity tag tells if the user changed it
+		lastModified = time.Time{}
+	}
+	// in_watchlist depends on the user, who is given by the cookie
+	writer.Header().Set("Vary", "Cookie")
+	if notModified(writer, request, etag, lastModified) {
+		writer.WriteHeader(http.StatusNotModified)
+		return
+	}
 
 	writer.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(writer).Encode(dto); err != nil {
+	if err := json.NewEncoder(writer).Encode(dtos[0]); err != nil {
 		writer.WriteHeader(http.StatusInternalServerError)
 		_, _ = writer.Write([]byte("Internal server error"))
 		return
@@ -124,16 +292,81 @@ func (h *Handler) GetMovieHandler(writer http.ResponseWriter, request *http.Requ
 }
 
 // GetMoviesHandler used to get movies with specified sorting, searching by title of movie or name of actor
+// and full-text search over titles, descriptions and casts ranked by relevance.
+// Movies are returned page by page, next page is available by the returned cursor
 func (h *Handler) GetMoviesHandler(writer http.ResponseWriter, request *http.Request) {
-	sort, filter := buildSortingAndFilter(request.URL.Query())
+	sort, filter, err := buildSortingAndFilter(request.URL.Query())
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid query parameters: " + err.Error()))
+		return
+	}
+	page, err := parsePage(request.URL.Query())
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid limit"))
+		return
+	}
+
+	movies, next, err := h.mov.ListMovies(request.Context(), filter, sort, page)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
 
-	movies, err := h.mov.ListMovies(request.Context(), filter, sort)
+	var suggestions []string
+	if len(movies) == 0 && page.Cursor == "" {
+		suggestions, err = h.mov.SuggestSpellings(request.Context(), filter)
+		if err != nil {
+			h.HandleServiceError(writer, err)
+			return
+		}
+	}
+
+	dtos := make([]MovieDTO, 0, len(movies))
+	for _, m := range movies {
+		dtos = append(dtos, movieToDTO(m))
+	}
+	if len(dtos) == 0 && len(suggestions) == 0 {
+		writer.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if err := h.markWatchlist(request, dtos); err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	setNextLink(writer, request, next)
+	writer.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(writer).Encode(MovieListDTO{Movies: dtos, NextCursor: next, Suggestions: suggestions}); err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		_, _ = writer.Write([]byte("Internal server error"))
+		return
+	}
+}
+
+// GetSimilarMoviesHandler used to get movies sharing the cast with the given one, the most similar go first
+func (h *Handler) GetSimilarMoviesHandler(writer http.ResponseWriter, request *http.Request) {
+	id, err := strconv.Atoi(request.PathValue("id"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid movie id"))
+		return
+	}
+	page, err := parsePage(request.URL.Query())
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid limit"))
+		return
+	}
+
+	movies, err := h.mov.SimilarMovies(request.Context(), id, page.Limit)
 	if err != nil {
 		h.HandleServiceError(writer, err)
 		return
 	}
 
-	var dtos []MovieDTO
+	dtos := make([]MovieDTO, 0, len(movies))
 	for _, m := range movies {
 		dtos = append(dtos, movieToDTO(m))
 	}
@@ -141,15 +374,21 @@ func (h *Handler) GetMoviesHandler(writer http.ResponseWriter, request *http.Req
 		writer.WriteHeader(http.StatusNoContent)
 		return
 	}
+	if err := h.markWatchlist(request, dtos); err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
 
 	writer.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(writer).Encode(dtos); err != nil {
+	if err := json.NewEncoder(writer).Encode(MovieListDTO{Movies: dtos}); err != nil {
 		writer.WriteHeader(http.StatusInternalServerError)
 		_, _ = writer.Write([]byte("Internal server error"))
 		return
 	}
 }
 
+// UpdateMovieHandler replaces the movie with the one in the body, fields left out are cleared,
+// so the movie is validated as a whole
 func (h *Handler) UpdateMovieHandler(writer http.ResponseWriter, request *http.Request) {
 	id, err := strconv.Atoi(request.PathValue("id"))
 	if err != nil {
@@ -163,54 +402,95 @@ func (h *Handler) UpdateMovieHandler(writer http.ResponseWriter, request *http.R
 		return
 	}
 
+	version, err := parseIfMatch(request, h.movieVersion, id)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
 	mov := &MovieRequest{}
-	if err := json.NewDecoder(request.Body).Decode(mov); err != nil {
+	if err := decodeStrict(request.Body, mov); err != nil {
 		writer.WriteHeader(http.StatusBadRequest)
 		_, _ = writer.Write([]byte("Invalid request body"))
 		return
 	}
 
-	actors := make([]*domain.Actor, 0, len(mov.Actors))
-	for _, id := range mov.Actors {
-		actor, err := h.act.GetActorById(request.Context(), id)
-		if err != nil {
-			h.HandleServiceError(writer, err)
-			return
-		}
-		actors = append(actors, actor)
-	}
+	h.saveMovie(writer, request, id, version, mov)
+}
 
-	oldMovie, err := h.mov.GetMovieById(request.Context(), id)
+// PatchMovieHandler changes the fields of the movie given in the JSON Merge Patch, null clears the field
+func (h *Handler) PatchMovieHandler(writer http.ResponseWriter, request *http.Request) {
+	id, err := strconv.Atoi(request.PathValue("id"))
 	if err != nil {
-		h.HandleServiceError(writer, err)
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid movie id"))
+		return
+	}
+
+	if !isAdminRole(request) {
+		h.HandleServiceError(writer, domain.ErrNotAdmin)
 		return
 	}
 
-	if mov.Title != "" {
-		oldMovie.Title = mov.Title
+	if !isMergePatch(request) {
+		writer.Header().Set("Accept-Patch", mergePatchType)
+		writer.WriteHeader(http.StatusUnsupportedMediaType)
+		_, _ = writer.Write([]byte("Request body must be " + mergePatchType))
+		return
 	}
-	if mov.Description != "" {
-		oldMovie.Description = mov.Description
+
+	version, err := parseIfMatch(request, h.movieVersion, id)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
 	}
 
-	if !mov.ReleaseDate.IsZero() {
-		oldMovie.ReleaseDate = mov.ReleaseDate
+	oldMovie, err := h.mov.GetMovieById(request.Context(), id)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
 	}
 
-	if mov.Rating != 0 {
-		oldMovie.Rating = mov.Rating
+	mov := &MovieRequest{}
+	if err := applyMergePatch(movieToRequest(oldMovie), request.Body, mov); err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid request body"))
+		return
 	}
 
-	if len(actors) > 0 {
-		oldMovie.Actors = actors
+	h.saveMovie(writer, request, id, version, mov)
+}
+
+// saveMovie updates the movie to the state in the request unless it was changed since the version
+func (h *Handler) saveMovie(writer http.ResponseWriter, request *http.Request, id int, version int, mov *MovieRequest) {
+	actors, err := h.getCast(request, mov.Actors)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
 	}
 
-	err = h.mov.UpdateMovie(request.Context(), oldMovie)
+	genres, err := h.getGenres(request, mov.Genres)
 	if err != nil {
 		h.HandleServiceError(writer, err)
 		return
 	}
 
+	movie := &domain.Movie{
+		Id:          id,
+		Title:       mov.Title,
+		Description: mov.Description,
+		ReleaseDate: mov.ReleaseDate,
+		Rating:      mov.Rating,
+		Actors:      actors,
+		Genres:      genres,
+		Version:     version,
+	}
+	if err := h.mov.UpdateMovie(request.Context(), movie); err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	setETag(writer, movie.Version)
 	writer.WriteHeader(http.StatusNoContent)
 }
 
@@ -227,7 +507,13 @@ func (h *Handler) DeleteMovieHandler(writer http.ResponseWriter, request *http.R
 		return
 	}
 
-	err = h.mov.DeleteMovie(request.Context(), id)
+	version, err := parseIfMatch(request, h.movieVersion, id)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	err = h.mov.DeleteMovie(request.Context(), id, version)
 	if err != nil {
 		h.HandleServiceError(writer, err)
 		return
@@ -237,47 +523,164 @@ func (h *Handler) DeleteMovieHandler(writer http.ResponseWriter, request *http.R
 
 }
 
-// buildSortingAndFilter gets sorting and filter parameters from request and returns them as movie.SortBy and *movie.Filter
-func buildSortingAndFilter(u url.Values) (movie.SortBy, *movie.Filter) {
-	sortParam := u.Get("sort")
-	var sort movie.SortBy
-	switch sortParam {
-	case "rating":
-		sort = movie.SortByRating
-	case "release_date":
-		sort = movie.SortByReleaseDate
-	case "name":
-		sort = movie.SortByTitle
-	default:
-		sort = movie.DefaultSort
+var movieSortFields = map[string]movie.SortBy{
+	"rating":       movie.SortByRating,
+	"release_date": movie.SortByReleaseDate,
+	"name":         movie.SortByTitle,
+	"relevance":    movie.SortByRelevance,
+	"added":        movie.SortByAdded,
+}
+
+// buildSortingAndFilter gets sorting and filter parameters from request and returns them as movie.Sorting and *movie.Filter
+func buildSortingAndFilter(u url.Values) (movie.Sorting, *movie.Filter, error) {
+	params, err := parseSort(u)
+	if err != nil {
+		return nil, nil, err
+	}
+	var sort movie.Sorting
+	for _, param := range params {
+		field, ok := movieSortFields[param.field]
+		if param.field == "" {
+			// the default field depends on the listing, the service puts it in place
+			field, ok = movie.SortByDefault, true
+		}
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown sort field %q", param.field)
+		}
+		key := movie.NewSortKey(field)
+		if param.desc != nil {
+			key.Desc = *param.desc
+		}
+		sort = append(sort, key)
 	}
 
 	filter := movie.NewFilter()
+	if query := u.Get("q"); query != "" {
+		filter = filter.WithQuery(query)
+	}
 	if name := u.Get("name"); name != "" {
 		filter = filter.WithTitle(name)
 	}
-	if releaseDate := u.Get("release_date"); releaseDate != "" {
-		parsedDate, err := time.Parse(time.DateOnly, releaseDate)
-		if err == nil {
-			filter = filter.WithReleaseDate(parsedDate)
+
+	// release_date and rating are the older names of release_date_from and rating_min
+	for _, param := range []string{"release_date", "release_date_from", "release_date_to"} {
+		value := u.Get(param)
+		if value == "" {
+			continue
+		}
+		parsedDate, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s must be a date in YYYY-MM-DD format", param)
 		}
+		if param == "release_date_to" {
+			filter = filter.WithReleaseDateTo(parsedDate)
+		} else {
+			filter = filter.WithReleaseDateFrom(parsedDate)
+		}
+	}
+	// updated_since lets clients sync incrementally, giving back the time of their last sync
+	if value := u.Get("updated_since"); value != "" {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			t, err = time.Parse(time.DateOnly, value)
+			if err != nil {
+				return nil, nil, errors.New("updated_since must be a time in RFC 3339 or a date in YYYY-MM-DD format")
+			}
+		}
+		filter = filter.WithUpdatedSince(t)
+	}
+	if genreParams := u["genre"]; len(genreParams) > 0 {
+		var ids []int
+		for _, param := range genreParams {
+			for _, value := range strings.Split(param, ",") {
+				id, err := strconv.Atoi(value)
+				if err != nil {
+					return nil, nil, errors.New("genre must be a list of genre ids")
+				}
+				ids = append(ids, id)
+			}
+		}
+		match := u.Get("genre_match")
+		if match != "" && match != "any" && match != "all" {
+			return nil, nil, errors.New("genre_match must be 'any' or 'all'")
+		}
+		filter = filter.WithGenres(ids, match == "all")
+	}
+	for _, param := range []string{"rating", "rating_min", "rating_max"} {
+		value := u.Get(param)
+		if value == "" {
+			continue
+		}
+		parsedRating, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s must be a number", param)
+		}
+		if param == "rating_max" {
+			filter = filter.WithRatingMax(parsedRating)
+		} else {
+			filter = filter.WithRatingMin(parsedRating)
+		}
+	}
+
+	return sort, filter, nil
+}
+
+// getGenres loads genres by ids, so unknown ones are reported before the movie is written
+func (h *Handler) getGenres(request *http.Request, ids []int) ([]*domain.Genre, error) {
+	genres := make([]*domain.Genre, 0, len(ids))
+	for _, id := range ids {
+		genre, err := h.gen.GetGenreById(request.Context(), id)
+		if err != nil {
+			return nil, err
+		}
+		genres = append(genres, genre)
 	}
-	if rating := u.Get("rating"); rating != "" {
-		parsedRating, err := strconv.ParseFloat(rating, 64)
-		if err == nil {
-			filter = filter.WithRating(parsedRating)
+
+	return genres, nil
+}
+
+// getCast resolves the credited actors
+func (h *Handler) getCast(request *http.Request, credits []CastRequest) ([]*domain.CastMember, error) {
+	cast := make([]*domain.CastMember, 0, len(credits))
+	for _, credit := range credits {
+		actor, err := h.act.GetActorById(request.Context(), credit.ActorId)
+		if err != nil {
+			return nil, err
 		}
+		cast = append(cast, &domain.CastMember{Actor: actor, Character: credit.Character, Billing: credit.Billing})
 	}
 
-	fmt.Println(sort, filter)
+	return cast, nil
+}
+
+// movieToRequest is the movie as admins send it, patches are applied to it
+func movieToRequest(m *domain.Movie) MovieRequest {
+	mov := MovieRequest{
+		Title:       m.Title,
+		Description: m.Description,
+		ReleaseDate: m.ReleaseDate,
+		Rating:      m.Rating,
+		Actors:      make([]CastRequest, 0, len(m.Actors)),
+		Genres:      make([]int, 0, len(m.Genres)),
+	}
+	for _, a := range m.Actors {
+		mov.Actors = append(mov.Actors, CastRequest{ActorId: a.Id, Character: a.Character, Billing: a.Billing})
+	}
+	for _, g := range m.Genres {
+		mov.Genres = append(mov.Genres, g.Id)
+	}
 
-	return sort, filter
+	return mov
 }
 
 func movieToDTO(m *domain.Movie) MovieDTO {
-	actors := make([]ActorDTO, 0, len(m.Actors))
+	actors := make([]CastMemberDTO, 0, len(m.Actors))
 	for _, a := range m.Actors {
-		actors = append(actors, actorToDTO(a))
+		actors = append(actors, CastMemberDTO{ActorDTO: actorToDTO(a.Actor), Character: a.Character, Billing: a.Billing})
+	}
+	genres := make([]GenreDTO, 0, len(m.Genres))
+	for _, g := range m.Genres {
+		genres = append(genres, genreToDTO(g))
 	}
 
 	return MovieDTO{
@@ -286,6 +689,16 @@ func movieToDTO(m *domain.Movie) MovieDTO {
 		Description: m.Description,
 		ReleaseDate: m.ReleaseDate,
 		Rating:      m.Rating,
+		Score:       m.Score,
+		ReviewCount: m.ReviewCount,
 		Actors:      actors,
+		Genres:      genres,
+		Relevance:   m.Relevance,
+		Highlight:   m.Headline,
+		AddedAt:     m.AddedAt,
+		Similarity:  m.Similarity,
+		DeletedAt:   m.DeletedAt,
+		CreatedAt:   timestamp(m.CreatedAt),
+		UpdatedAt:   timestamp(m.UpdatedAt),
 	}
 }
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+	"vk-backend/internal/domain"
+	"vk-backend/internal/service/collection"
+	"vk-backend/internal/service/movie"
+	"vk-backend/mocks"
+)
+
+func getMovie(h *Handler, header http.Header, userId int) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, "/movies/1", nil)
+	request.SetPathValue("id", "1")
+	request.Header = header
+	if userId != 0 {
+		request = request.WithContext(domain.WithUserId(request.Context(), userId))
+	}
+	recorder := httptest.NewRecorder()
+	h.GetMovieHandler(recorder, request)
+
+	return recorder
+}
+
+func TestGetMovieHandler_NotModified(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	h := &Handler{mov: movie.NewService(repo)}
+
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	stored := &domain.Movie{
+		Id:          1,
+		Title:       "title",
+		Description: "description",
+		ReleaseDate: time.Date(1999, 3, 31, 0, 0, 0, 0, time.UTC),
+		Score:       5,
+		Version:     3,
+		UpdatedAt:   updatedAt,
+	}
+	repo.EXPECT().MovieExists(gomock.Any(), 1).Return(true, nil).AnyTimes()
+	repo.EXPECT().GetMovieById(gomock.Any(), 1).DoAndReturn(func(_ any, _ int) (*domain.Movie, error) {
+		m := *stored
+		return &m, nil
+	}).AnyTimes()
+
+	first := getMovie(h, http.Header{}, 0)
+	assert.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	assert.Regexp(t, `^"3-[0-9a-f]+"$`, etag)
+	assert.Equal(t, updatedAt.Format(http.TimeFormat), first.Header().Get("Last-Modified"))
+
+	resp := getMovie(h, http.Header{"If-None-Match": {etag}}, 0)
+	assert.Equal(t, http.StatusNotModified, resp.Code)
+	assert.Empty(t, resp.Body.String())
+
+	resp = getMovie(h, http.Header{"If-Modified-Since": {updatedAt.Format(http.TimeFormat)}}, 0)
+	assert.Equal(t, http.StatusNotModified, resp.Code)
+
+	// a review changes the score but not the version
+	stored.Score = 7
+	stored.UpdatedAt = updatedAt.Add(time.Minute)
+	resp = getMovie(h, http.Header{"If-None-Match": {etag}}, 0)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.NotEqual(t, etag, resp.Header().Get("ETag"))
+
+	resp = getMovie(h, http.Header{"If-Modified-Since": {updatedAt.Format(http.TimeFormat)}}, 0)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	// so do the names of the cast
+	etag = resp.Header().Get("ETag")
+	stored.Actors = []*domain.CastMember{{Actor: &domain.Actor{Id: 1, Name: "renamed"}, Billing: 1}}
+	resp = getMovie(h, http.Header{"If-None-Match": {etag}}, 0)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestGetMovieHandler_NotModifiedWatchlist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	colRepo := mocks.NewMockCollectionRepository(ctrl)
+	h := &Handler{mov: movie.NewService(repo), col: collection.NewService(colRepo)}
+
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	repo.EXPECT().MovieExists(gomock.Any(), 1).Return(true, nil).AnyTimes()
+	repo.EXPECT().GetMovieById(gomock.Any(), 1).DoAndReturn(func(_ any, _ int) (*domain.Movie, error) {
+		return &domain.Movie{Id: 1, Title: "title", Version: 3, UpdatedAt: updatedAt}, nil
+	}).AnyTimes()
+	watchlist := domain.UserCollection{UserId: 7, Collection: domain.Watchlist}
+	colRepo.EXPECT().InCollection(gomock.Any(), watchlist, []int{1}).Return(map[int]bool{}, nil)
+	colRepo.EXPECT().InCollection(gomock.Any(), watchlist, []int{1}).Return(map[int]bool{1: true}, nil).Times(2)
+
+	first := getMovie(h, http.Header{}, 7)
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "Cookie", first.Header().Get("Vary"))
+	assert.Empty(t, first.Header().Get("Last-Modified"))
+
+	// the movie didn't change, but the user added it to the watchlist
+	resp := getMovie(h, http.Header{"If-None-Match": {first.Header().Get("ETag")}}, 7)
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	resp = getMovie(h, http.Header{"If-Modified-Since": {updatedAt.Format(http.TimeFormat)}}, 7)
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestParseIfMatch(t *testing.T) {
+	current := func(_ context.Context, id int) (int, error) {
+		if id != 1 {
+			return 0, domain.ErrMovieNotExists
+		}
+		return 3, nil
+	}
+	tests := []struct {
+		value   string
+		id      int
+		version int
+		err     error
+	}{
+		{value: "", err: domain.ErrVersionRequired},
+		{value: `"3"`, version: 3},
+		{value: `"3-9f86d081884c7d65"`, version: 3},
+		{value: `W/"3"`, err: domain.ErrVersionMismatch},
+		{value: `"x"`, err: domain.ErrVersionMismatch},
+		{value: `"0"`, err: domain.ErrVersionMismatch},
+		// a single tag is checked along with the change
+		{value: `"2"`, version: 2},
+		{value: `*`, id: 1, version: 3},
+		{value: `*`, id: 2, err: domain.ErrMovieNotExists},
+		{value: `"2", "3-9f86d081884c7d65"`, id: 1, version: 3},
+		{value: `"1","2"`, id: 1, err: domain.ErrVersionMismatch},
+		{value: `W/"3", "2"`, id: 1, version: 2},
+	}
+
+	for _, test := range tests {
+		request := httptest.NewRequest(http.MethodPut, "/movies/1", nil)
+		if test.value != "" {
+			request.Header.Set("If-Match", test.value)
+		}
+		version, err := parseIfMatch(request, current, test.id)
+		assert.ErrorIs(t, err, test.err, test.value)
+		assert.Equal(t, test.version, version, test.value)
+	}
+}
+
+func TestBuildSortingAndFilter(t *testing.T) {
+	since := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		query   string
+		sorting movie.Sorting
+		filter  *movie.Filter
+		err     bool
+	}{
+		{query: "", filter: movie.NewFilter()},
+		{
+			query:   "sort=name,-release_date",
+			sorting: movie.Sorting{{Field: movie.SortByTitle}, {Field: movie.SortByReleaseDate, Desc: true}},
+			filter:  movie.NewFilter(),
+		},
+		{query: "sort=+rating", sorting: movie.Sorting{{Field: movie.SortByRating}}, filter: movie.NewFilter()},
+		{query: "sort=rating&order=asc", sorting: movie.Sorting{{Field: movie.SortByRating}}, filter: movie.NewFilter()},
+		{query: "order=asc", sorting: movie.Sorting{{Field: movie.SortByDefault}}, filter: movie.NewFilter()},
+		{
+			query:   "q=matrix&order=desc",
+			sorting: movie.Sorting{{Field: movie.SortByDefault, Desc: true}},
+			filter:  movie.NewFilter().WithQuery("matrix"),
+		},
+		{query: "updated_since=2024-05-01T12:00:00Z", filter: movie.NewFilter().WithUpdatedSince(since)},
+		{query: "genre=1,2&genre_match=all", filter: movie.NewFilter().WithGenres([]int{1, 2}, true)},
+		{query: "sort=popularity", err: true},
+		{query: "order=up", err: true},
+		{query: "genre_match=any&genre=x", err: true},
+		{query: "updated_since=yesterday", err: true},
+	}
+
+	for _, test := range tests {
+		u, err := url.ParseQuery(test.query)
+		if !assert.NoError(t, err, test.query) {
+			continue
+		}
+		sorting, filter, err := buildSortingAndFilter(u)
+		if test.err {
+			assert.Error(t, err, test.query)
+			continue
+		}
+		if assert.NoError(t, err, test.query) {
+			assert.Equal(t, test.sorting, sorting, test.query)
+			assert.Equal(t, test.filter, filter, test.query)
+		}
+	}
+}
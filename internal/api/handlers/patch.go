@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+)
+
+const mergePatchType = "application/merge-patch+json"
+
+// isMergePatch tells if the body of the PATCH request is a JSON Merge Patch (RFC 7396),
+// plain JSON isn't taken, so nulls clear fields only for clients that asked for it
+func isMergePatch(request *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(request.Header.Get("Content-Type"))
+	return err == nil && mediaType == mergePatchType
+}
+
+// applyMergePatch applies the patch to the JSON form of the record and decodes the result into dst.
+// Nulls remove fields, so they get zero values, objects are merged and anything else replaces the field.
+// Unknown fields are rejected, otherwise a misspelled field would be ignored silently.
+func applyMergePatch(record any, patch io.Reader, dst any) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	var target any
+	if err := json.Unmarshal(data, &target); err != nil {
+		return err
+	}
+
+	var changes any
+	decoder := json.NewDecoder(patch)
+	decoder.UseNumber()
+	if err := decoder.Decode(&changes); err != nil {
+		return err
+	}
+
+	data, err = json.Marshal(mergePatch(target, changes))
+	if err != nil {
+		return err
+	}
+
+	return decodeStrict(bytes.NewReader(data), dst)
+}
+
+// mergePatch is the MergePatch function of RFC 7396
+func mergePatch(target any, patch any) any {
+	changes, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	fields, ok := target.(map[string]any)
+	if !ok {
+		fields = map[string]any{}
+	}
+	for name, value := range changes {
+		if value == nil {
+			delete(fields, name)
+			continue
+		}
+		fields[name] = mergePatch(fields[name], value)
+	}
+
+	return fields
+}
+
+// decodeStrict decodes the whole record, that must not have unknown fields
+func decodeStrict(body io.Reader, dst any) error {
+	decoder := json.NewDecoder(body)
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(dst)
+}
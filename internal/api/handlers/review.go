@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+	"vk-backend/internal/domain"
+)
+
+type ReviewRequest struct {
+	Rating *int   `json:"rating"` // from 0 to 10, required
+	Text   string `json:"text"`
+}
+
+type ReviewDTO struct {
+	Id        int       `json:"id"`
+	MovieId   int       `json:"movie_id"`
+	UserId    int       `json:"user_id"`
+	Username  string    `json:"username"`
+	Rating    int       `json:"rating"`
+	Text      string    `json:"text,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ReviewListDTO struct {
+	Reviews    []ReviewDTO `json:"reviews"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+// SaveMyReviewHandler used to rate and review a movie by the current user, a repeated request edits the review
+func (h *Handler) SaveMyReviewHandler(writer http.ResponseWriter, request *http.Request) {
+	req := &ReviewRequest{}
+	if err := json.NewDecoder(request.Body).Decode(req); err != nil || req.Rating == nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid request body"))
+		return
+	}
+
+	userId, ok := currentUserId(request)
+	if !ok {
+		h.HandleServiceError(writer, domain.ErrNotAuthorized)
+		return
+	}
+
+	movieId, err := strconv.Atoi(request.PathValue("id"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid movie id"))
+		return
+	}
+
+	review, err := h.rev.SaveReview(request.Context(), movieId, userId, *req.Rating, req.Text)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(writer).Encode(reviewToDTO(review)); err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		_, _ = writer.Write([]byte("Internal server error"))
+		return
+	}
+}
+
+func (h *Handler) GetMyReviewHandler(writer http.ResponseWriter, request *http.Request) {
+	userId, ok := currentUserId(request)
+	if !ok {
+		h.HandleServiceError(writer, domain.ErrNotAuthorized)
+		return
+	}
+
+	movieId, err := strconv.Atoi(request.PathValue("id"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid movie id"))
+		return
+	}
+
+	review, err := h.rev.GetReview(request.Context(), movieId, userId)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(writer).Encode(reviewToDTO(review)); err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		_, _ = writer.Write([]byte("Internal server error"))
+		return
+	}
+}
+
+func (h *Handler) DeleteMyReviewHandler(writer http.ResponseWriter, request *http.Request) {
+	userId, ok := currentUserId(request)
+	if !ok {
+		h.HandleServiceError(writer, domain.ErrNotAuthorized)
+		return
+	}
+
+	movieId, err := strconv.Atoi(request.PathValue("id"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid movie id"))
+		return
+	}
+
+	if err := h.rev.DeleteReview(request.Context(), movieId, userId); err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// GetReviewsHandler used to get reviews of a movie page by page, the newest go first
+func (h *Handler) GetReviewsHandler(writer http.ResponseWriter, request *http.Request) {
+	movieId, err := strconv.Atoi(request.PathValue("id"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid movie id"))
+		return
+	}
+	page, err := parsePage(request.URL.Query())
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid limit"))
+		return
+	}
+
+	reviews, next, err := h.rev.ListReviews(request.Context(), movieId, page)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	dtos := make([]ReviewDTO, 0, len(reviews))
+	for _, r := range reviews {
+		dtos = append(dtos, reviewToDTO(r))
+	}
+	if len(dtos) == 0 {
+		writer.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	setNextLink(writer, request, next)
+	writer.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(writer).Encode(ReviewListDTO{Reviews: dtos, NextCursor: next}); err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		_, _ = writer.Write([]byte("Internal server error"))
+		return
+	}
+}
+
+func reviewToDTO(r *domain.Review) ReviewDTO {
+	return ReviewDTO{
+		Id:        r.Id,
+		MovieId:   r.MovieId,
+		UserId:    r.UserId,
+		Username:  r.Username,
+		Rating:    r.Rating,
+		Text:      r.Text,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
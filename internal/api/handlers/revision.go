@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+	"vk-backend/internal/domain"
+)
+
+type RevisionDTO struct {
+	Number int             `json:"number"`
+	UserId *int            `json:"user_id"` // null for the state before the first update
+	At     time.Time       `json:"at"`
+	Data   json.RawMessage `json:"data,omitempty"` // only for a single revision
+}
+
+type RevisionListDTO struct {
+	Revisions  []RevisionDTO `json:"revisions"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
+type FieldChangeDTO struct {
+	Field  string          `json:"field"`
+	Before json.RawMessage `json:"before"` // null when the field is missing
+	After  json.RawMessage `json:"after"`
+}
+
+// revisionService is implemented by services of entities, that are saved as revisions on every update
+type revisionService interface {
+	ListRevisions(ctx context.Context, id int, page domain.Page) ([]*domain.Revision, string, error)
+	GetRevision(ctx context.Context, id int, number int) (*domain.Revision, error)
+	RestoreRevision(ctx context.Context, id int, number int, version int) (int, error)
+	DiffRevisions(ctx context.Context, id int, from int, to int) ([]domain.FieldChange, error)
+}
+
+// GetMovieRevisionsHandler used to list earlier versions of a movie, the latest first (admin only)
+func (h *Handler) GetMovieRevisionsHandler(writer http.ResponseWriter, request *http.Request) {
+	h.listRevisions(writer, request, h.mov, "movie")
+}
+
+// GetMovieRevisionHandler used to get a version of a movie, cast and genres are given by ids (admin only)
+func (h *Handler) GetMovieRevisionHandler(writer http.ResponseWriter, request *http.Request) {
+	h.getRevision(writer, request, h.mov, "movie")
+}
+
+// RestoreMovieRevisionHandler used to bring a movie back to one of its versions (admin only),
+// If-Match must have the ETag of the current movie
+func (h *Handler) RestoreMovieRevisionHandler(writer http.ResponseWriter, request *http.Request) {
+	h.restoreRevision(writer, request, h.mov, "movie", h.movieVersion)
+}
+
+// DiffMovieRevisionsHandler used to get fields of a movie changed between two versions (admin only)
+func (h *Handler) DiffMovieRevisionsHandler(writer http.ResponseWriter, request *http.Request) {
+	h.diffRevisions(writer, request, h.mov, "movie")
+}
+
+// GetActorRevisionsHandler used to list earlier versions of an actor, the latest first (admin only)
+func (h *Handler) GetActorRevisionsHandler(writer http.ResponseWriter, request *http.Request) {
+	h.listRevisions(writer, request, h.act, "actor")
+}
+
+// GetActorRevisionHandler used to get a version of an actor (admin only)
+func (h *Handler) GetActorRevisionHandler(writer http.ResponseWriter, request *http.Request) {
+	h.getRevision(writer, request, h.act, "actor")
+}
+
+// RestoreActorRevisionHandler used to bring an actor back to one of their versions (admin only),
+// If-Match must have the ETag of the current actor
+func (h *Handler) RestoreActorRevisionHandler(writer http.ResponseWriter, request *http.Request) {
+	h.restoreRevision(writer, request, h.act, "actor", h.actorVersion)
+}
+
+// DiffActorRevisionsHandler used to get fields of an actor changed between two versions (admin only)
+func (h *Handler) DiffActorRevisionsHandler(writer http.ResponseWriter, request *http.Request) {
+	h.diffRevisions(writer, request, h.act, "actor")
+}
+
+func (h *Handler) listRevisions(writer http.ResponseWriter, request *http.Request, srv revisionService, entity string) {
+	id, err := strconv.Atoi(request.PathValue("id"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid " + entity + " id"))
+		return
+	}
+	if !isAdminRole(request) {
+		h.HandleServiceError(writer, domain.ErrNotAdmin)
+		return
+	}
+	page, err := parsePage(request.URL.Query())
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid limit"))
+		return
+	}
+
+	revisions, next, err := srv.ListRevisions(request.Context(), id, page)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+	if len(revisions) == 0 {
+		writer.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	dtos := make([]RevisionDTO, 0, len(revisions))
+	for _, r := range revisions {
+		dtos = append(dtos, revisionToDTO(r))
+	}
+
+	setNextLink(writer, request, next)
+	writer.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(writer).Encode(RevisionListDTO{Revisions: dtos, NextCursor: next}); err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		_, _ = writer.Write([]byte("Internal server error"))
+		return
+	}
+}
+
+func (h *Handler) getRevision(writer http.ResponseWriter, request *http.Request, srv revisionService, entity string) {
+	id, number, ok := parseRevisionPath(writer, request, entity)
+	if !ok {
+		return
+	}
+	if !isAdminRole(request) {
+		h.HandleServiceError(writer, domain.ErrNotAdmin)
+		return
+	}
+
+	revision, err := srv.GetRevision(request.Context(), id, number)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(writer).Encode(revisionToDTO(revision)); err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		_, _ = writer.Write([]byte("Internal server error"))
+		return
+	}
+}
+
+func (h *Handler) restoreRevision(writer http.ResponseWriter, request *http.Request, srv revisionService, entity string,
+	current func(ctx context.Context, id int) (int, error)) {
+	id, number, ok := parseRevisionPath(writer, request, entity)
+	if !ok {
+		return
+	}
+	if !isAdminRole(request) {
+		h.HandleServiceError(writer, domain.ErrNotAdmin)
+		return
+	}
+
+	version, err := parseIfMatch(request, current, id)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	version, err = srv.RestoreRevision(request.Context(), id, number, version)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	setETag(writer, version)
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) diffRevisions(writer http.ResponseWriter, request *http.Request, srv revisionService, entity string) {
+	id, from, ok := parseRevisionPath(writer, request, entity)
+	if !ok {
+		return
+	}
+	to, err := strconv.Atoi(request.PathValue("to"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid revision number"))
+		return
+	}
+	if !isAdminRole(request) {
+		h.HandleServiceError(writer, domain.ErrNotAdmin)
+		return
+	}
+
+	changes, err := srv.DiffRevisions(request.Context(), id, from, to)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	dtos := make([]FieldChangeDTO, 0, len(changes))
+	for _, c := range changes {
+		dtos = append(dtos, FieldChangeDTO{Field: c.Field, Before: c.Before, After: c.After})
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(writer).Encode(dtos); err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		_, _ = writer.Write([]byte("Internal server error"))
+		return
+	}
+}
+
+// parseRevisionPath reads the id of the entity and the number of the revision, it writes the response if they are invalid
+func parseRevisionPath(writer http.ResponseWriter, request *http.Request, entity string) (int, int, bool) {
+	id, err := strconv.Atoi(request.PathValue("id"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid " + entity + " id"))
+		return 0, 0, false
+	}
+	number, err := strconv.Atoi(request.PathValue("n"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid revision number"))
+		return 0, 0, false
+	}
+
+	return id, number, true
+}
+
+func revisionToDTO(r *domain.Revision) RevisionDTO {
+	return RevisionDTO{
+		Number: r.Number,
+		UserId: r.UserId,
+		At:     r.At,
+		Data:   r.Data,
+	}
+}
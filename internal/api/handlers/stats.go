@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type YearStatsDTO struct {
+	Year          int     `json:"year"`
+	MovieCount    int     `json:"movie_count"`
+	AverageRating float64 `json:"average_rating"`
+	AverageScore  float64 `json:"average_score"`
+}
+
+type RatingBucketDTO struct {
+	From       float64 `json:"from"`
+	To         float64 `json:"to"`
+	MovieCount int     `json:"movie_count"`
+}
+
+type ProlificActorDTO struct {
+	ActorDTO
+	FirstRelease time.Time `json:"first_release"`
+	LastRelease  time.Time `json:"last_release"`
+}
+
+type AgeBucketDTO struct {
+	From  int `json:"from"`
+	To    int `json:"to"`
+	Roles int `json:"roles"`
+}
+
+type AgeStatsDTO struct {
+	Roles      int            `json:"roles"`
+	AverageAge float64        `json:"average_age"`
+	Youngest   int            `json:"youngest"`
+	Oldest     int            `json:"oldest"`
+	Buckets    []AgeBucketDTO `json:"buckets"`
+}
+
+// GetYearStatsHandler used to get the number of movies and their average ratings by release year
+func (h *Handler) GetYearStatsHandler(writer http.ResponseWriter, request *http.Request) {
+	years, err := h.stat.MoviesByYear(request.Context())
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	dtos := make([]YearStatsDTO, 0, len(years))
+	for _, y := range years {
+		dtos = append(dtos, YearStatsDTO{
+			Year:          y.Year,
+			MovieCount:    y.MovieCount,
+			AverageRating: y.AverageRating,
+			AverageScore:  y.AverageScore,
+		})
+	}
+
+	writeStats(writer, dtos)
+}
+
+// GetRatingHistogramHandler used to get the number of movies by score, buckets sets the number of bars
+func (h *Handler) GetRatingHistogramHandler(writer http.ResponseWriter, request *http.Request) {
+	buckets := 0
+	if v := request.URL.Query().Get("buckets"); v != "" {
+		var err error
+		if buckets, err = strconv.Atoi(v); err != nil || buckets <= 0 {
+			writer.WriteHeader(http.StatusBadRequest)
+			_, _ = writer.Write([]byte("Invalid buckets"))
+			return
+		}
+	}
+
+	histogram, err := h.stat.RatingHistogram(request.Context(), buckets)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	dtos := make([]RatingBucketDTO, 0, len(histogram))
+	for _, b := range histogram {
+		dtos = append(dtos, RatingBucketDTO{From: b.From, To: b.To, MovieCount: b.MovieCount})
+	}
+
+	writeStats(writer, dtos)
+}
+
+// GetProlificActorsHandler used to get actors who played in the most movies, limit sets the number of actors
+func (h *Handler) GetProlificActorsHandler(writer http.ResponseWriter, request *http.Request) {
+	page, err := parsePage(request.URL.Query())
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid limit"))
+		return
+	}
+
+	actors, err := h.stat.ProlificActors(request.Context(), page.Limit)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	dtos := make([]ProlificActorDTO, 0, len(actors))
+	for _, a := range actors {
+		dtos = append(dtos, ProlificActorDTO{
+			ActorDTO:     actorToDTO(a.Actor),
+			FirstRelease: a.FirstRelease,
+			LastRelease:  a.LastRelease,
+		})
+	}
+
+	writeStats(writer, dtos)
+}
+
+// GetActorAgesHandler used to get how old actors were at the release of their movies, actor_id narrows it to one actor
+func (h *Handler) GetActorAgesHandler(writer http.ResponseWriter, request *http.Request) {
+	var actorId *int
+	if v := request.URL.Query().Get("actor_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			writer.WriteHeader(http.StatusBadRequest)
+			_, _ = writer.Write([]byte("Invalid actor id"))
+			return
+		}
+		actorId = &id
+	}
+
+	ages, err := h.stat.ActorAges(request.Context(), actorId)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	dto := AgeStatsDTO{
+		Roles:      ages.Roles,
+		AverageAge: ages.AverageAge,
+		Youngest:   ages.Youngest,
+		Oldest:     ages.Oldest,
+		Buckets:    make([]AgeBucketDTO, 0, len(ages.Buckets)),
+	}
+	for _, b := range ages.Buckets {
+		dto.Buckets = append(dto.Buckets, AgeBucketDTO{From: b.From, To: b.To, Roles: b.Roles})
+	}
+
+	writeStats(writer, dto)
+}
+
+// writeStats answers with the statistics, empty ones are still 200 so dashboards can draw them
+func writeStats(writer http.ResponseWriter, stats any) {
+	writer.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(writer).Encode(stats); err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		_, _ = writer.Write([]byte("Internal server error"))
+		return
+	}
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"vk-backend/internal/domain"
+)
+
+// ListDeletedMoviesHandler used to list movies in the trash, the most recently deleted first (admin only)
+func (h *Handler) ListDeletedMoviesHandler(writer http.ResponseWriter, request *http.Request) {
+	if !isAdminRole(request) {
+		h.HandleServiceError(writer, domain.ErrNotAdmin)
+		return
+	}
+	page, err := parsePage(request.URL.Query())
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid limit"))
+		return
+	}
+
+	movies, next, err := h.mov.ListDeletedMovies(request.Context(), page)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+	if len(movies) == 0 {
+		writer.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	dtos := make([]MovieDTO, 0, len(movies))
+	for _, m := range movies {
+		dtos = append(dtos, movieToDTO(m))
+	}
+
+	setNextLink(writer, request, next)
+	writer.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(writer).Encode(MovieListDTO{Movies: dtos, NextCursor: next}); err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		_, _ = writer.Write([]byte("Internal server error"))
+		return
+	}
+}
+
+// RestoreMovieHandler used to take a movie out of the trash (admin only)
+func (h *Handler) RestoreMovieHandler(writer http.ResponseWriter, request *http.Request) {
+	id, err := strconv.Atoi(request.PathValue("id"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid movie id"))
+		return
+	}
+
+	if !isAdminRole(request) {
+		h.HandleServiceError(writer, domain.ErrNotAdmin)
+		return
+	}
+
+	if err := h.mov.RestoreMovie(request.Context(), id); err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// PurgeMovieHandler used to delete a movie in the trash for good (admin only)
+func (h *Handler) PurgeMovieHandler(writer http.ResponseWriter, request *http.Request) {
+	id, err := strconv.Atoi(request.PathValue("id"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid movie id"))
+		return
+	}
+
+	if !isAdminRole(request) {
+		h.HandleServiceError(writer, domain.ErrNotAdmin)
+		return
+	}
+
+	if err := h.mov.PurgeMovie(request.Context(), id); err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeletedActorsHandler used to list actors in the trash, the most recently deleted first (admin only)
+func (h *Handler) ListDeletedActorsHandler(writer http.ResponseWriter, request *http.Request) {
+	if !isAdminRole(request) {
+		h.HandleServiceError(writer, domain.ErrNotAdmin)
+		return
+	}
+	page, err := parsePage(request.URL.Query())
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid limit"))
+		return
+	}
+
+	actors, next, err := h.act.ListDeletedActors(request.Context(), page)
+	if err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+	if len(actors) == 0 {
+		writer.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	dtos := make([]ActorDTO, 0, len(actors))
+	for _, a := range actors {
+		dtos = append(dtos, actorToDTO(a))
+	}
+
+	setNextLink(writer, request, next)
+	writer.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(writer).Encode(ActorListDTO{Actors: dtos, NextCursor: next}); err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		_, _ = writer.Write([]byte("Internal server error"))
+		return
+	}
+}
+
+// RestoreActorHandler used to take an actor out of the trash, the actor is back in the casts (admin only)
+func (h *Handler) RestoreActorHandler(writer http.ResponseWriter, request *http.Request) {
+	id, err := strconv.Atoi(request.PathValue("id"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid actor id"))
+		return
+	}
+
+	if !isAdminRole(request) {
+		h.HandleServiceError(writer, domain.ErrNotAdmin)
+		return
+	}
+
+	if err := h.act.RestoreActor(request.Context(), id); err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// PurgeActorHandler used to delete an actor in the trash for good (admin only)
+func (h *Handler) PurgeActorHandler(writer http.ResponseWriter, request *http.Request) {
+	id, err := strconv.Atoi(request.PathValue("id"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		_, _ = writer.Write([]byte("Invalid actor id"))
+		return
+	}
+
+	if !isAdminRole(request) {
+		h.HandleServiceError(writer, domain.ErrNotAdmin)
+		return
+	}
+
+	if err := h.act.PurgeActor(request.Context(), id); err != nil {
+		h.HandleServiceError(writer, err)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
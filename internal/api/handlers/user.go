@@ -83,6 +83,11 @@ func (h *Handler) LoginHandler(w http.ResponseWriter, r *http.Request) {
 
 }
 
+// currentUserId returns id of the authorized user
+func currentUserId(r *http.Request) (int, bool) {
+	return domain.UserIdFrom(r.Context())
+}
+
 func isAdminRole(r *http.Request) bool {
 	role := r.Context().Value("user_role").(string)
 	return role == "admin"
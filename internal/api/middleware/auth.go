@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"os"
 	"time"
+	"vk-backend/internal/domain"
 )
 
 func RequireAuth(next http.Handler) http.Handler {
@@ -27,7 +28,11 @@ func RequireAuth(next http.Handler) http.Handler {
 			if time.Now().Unix() > int64(claims["exp"].(float64)) {
 				w.WriteHeader(http.StatusUnauthorized)
 			} else {
-				ctx := context.WithValue(r.Context(), "user_id", claims["sub"])
+				ctx := r.Context()
+				// the token keeps the id as a JSON number
+				if sub, ok := claims["sub"].(float64); ok {
+					ctx = domain.WithUserId(ctx, int(sub))
+				}
 				ctx = context.WithValue(ctx, "user_role", claims["role"])
 				r = r.WithContext(ctx)
 			}
@@ -6,27 +6,72 @@ import (
 	"vk-backend/internal/api/handlers"
 	"vk-backend/internal/api/middleware"
 	"vk-backend/internal/service/actor"
+	"vk-backend/internal/service/audit"
+	"vk-backend/internal/service/collection"
+	"vk-backend/internal/service/genre"
+	"vk-backend/internal/service/importer"
 	"vk-backend/internal/service/movie"
+	"vk-backend/internal/service/review"
+	"vk-backend/internal/service/stats"
 	"vk-backend/internal/service/user"
 )
 
-func New(actorSrv *actor.ActorService, movieSrv *movie.MovieService, user *user.UserService, log *logrus.Logger) *http.ServeMux {
-	h := handlers.New(*actorSrv, *movieSrv, *user)
+func New(actorSrv *actor.ActorService, movieSrv *movie.MovieService, genreSrv *genre.GenreService, reviewSrv *review.ReviewService, collectionSrv *collection.CollectionService, statsSrv *stats.StatsService, importSrv *importer.ImportService, auditSrv *audit.AuditService, user *user.UserService, log *logrus.Logger) *http.ServeMux {
+	h := handlers.New(*actorSrv, *movieSrv, *genreSrv, *reviewSrv, *collectionSrv, *statsSrv, *importSrv, *auditSrv, *user)
 
 	mux := http.NewServeMux()
 	registerHandlerWithAuth(mux, "POST", "/actors", h.AddActorHandler, log)
 	registerHandlerWithAuth(mux, "GET", "/actors", h.GetAllActorsHandler, log)
 	registerHandlerWithAuth(mux, "GET", "/actors/{id}", h.GetActorHandler, log)
+	registerHandlerWithAuth(mux, "GET", "/actors/{id}/movies", h.GetActorMoviesHandler, log)
+	registerHandlerWithAuth(mux, "GET", "/actors/{id}/costars", h.GetCostarsHandler, log)
+	registerHandlerWithAuth(mux, "GET", "/actors/{a}/path/{b}", h.GetActorPathHandler, log)
 	registerHandlerWithAuth(mux, "PUT", "/actors/{id}", h.UpdateActorHandler, log)
-	registerHandlerWithAuth(mux, "PATCH", "/actors/{id}", h.UpdateActorHandler, log)
+	registerHandlerWithAuth(mux, "PATCH", "/actors/{id}", h.PatchActorHandler, log)
 	registerHandlerWithAuth(mux, "DELETE", "/actors/{id}", h.DeleteActorHandler, log)
+	registerHandlerWithAuth(mux, "GET", "/actors/{id}/revisions", h.GetActorRevisionsHandler, log)
+	registerHandlerWithAuth(mux, "GET", "/actors/{id}/revisions/{n}", h.GetActorRevisionHandler, log)
+	registerHandlerWithAuth(mux, "POST", "/actors/{id}/revisions/{n}/restore", h.RestoreActorRevisionHandler, log)
+	registerHandlerWithAuth(mux, "GET", "/actors/{id}/revisions/{n}/diff/{to}", h.DiffActorRevisionsHandler, log)
 	registerHandlerWithAuth(mux, "POST", "/movies", h.AddMovieHandler, log)
 	registerHandlerWithAuth(mux, "POST", "/movies/{id}/actors", h.AddActorToMovieHandler, log)
+	registerHandlerWithAuth(mux, "PUT", "/movies/{id}/actors", h.ReplaceCastHandler, log)
+	registerHandlerWithAuth(mux, "DELETE", "/movies/{id}/actors/{actorId}", h.RemoveActorFromMovieHandler, log)
 	registerHandlerWithAuth(mux, "GET", "/movies", h.GetMoviesHandler, log)
 	registerHandlerWithAuth(mux, "GET", "/movies/{id}", h.GetMovieHandler, log)
+	registerHandlerWithAuth(mux, "GET", "/movies/{id}/similar", h.GetSimilarMoviesHandler, log)
 	registerHandlerWithAuth(mux, "PUT", "/movies/{id}", h.UpdateMovieHandler, log)
-	registerHandlerWithAuth(mux, "PATCH", "/movies/{id}", h.UpdateMovieHandler, log)
+	registerHandlerWithAuth(mux, "PATCH", "/movies/{id}", h.PatchMovieHandler, log)
 	registerHandlerWithAuth(mux, "DELETE", "/movies/{id}", h.DeleteMovieHandler, log)
+	registerHandlerWithAuth(mux, "GET", "/movies/{id}/revisions", h.GetMovieRevisionsHandler, log)
+	registerHandlerWithAuth(mux, "GET", "/movies/{id}/revisions/{n}", h.GetMovieRevisionHandler, log)
+	registerHandlerWithAuth(mux, "POST", "/movies/{id}/revisions/{n}/restore", h.RestoreMovieRevisionHandler, log)
+	registerHandlerWithAuth(mux, "GET", "/movies/{id}/revisions/{n}/diff/{to}", h.DiffMovieRevisionsHandler, log)
+	registerHandlerWithAuth(mux, "GET", "/movies/{id}/reviews", h.GetReviewsHandler, log)
+	registerHandlerWithAuth(mux, "GET", "/movies/{id}/reviews/me", h.GetMyReviewHandler, log)
+	registerHandlerWithAuth(mux, "PUT", "/movies/{id}/reviews/me", h.SaveMyReviewHandler, log)
+	registerHandlerWithAuth(mux, "DELETE", "/movies/{id}/reviews/me", h.DeleteMyReviewHandler, log)
+	registerHandlerWithAuth(mux, "GET", "/me/{collection}", h.GetCollectionHandler, log)
+	registerHandlerWithAuth(mux, "POST", "/me/{collection}", h.AddToCollectionHandler, log)
+	registerHandlerWithAuth(mux, "DELETE", "/me/{collection}/{movieId}", h.RemoveFromCollectionHandler, log)
+	registerHandlerWithAuth(mux, "GET", "/stats/years", h.GetYearStatsHandler, log)
+	registerHandlerWithAuth(mux, "GET", "/stats/ratings", h.GetRatingHistogramHandler, log)
+	registerHandlerWithAuth(mux, "GET", "/stats/actors/prolific", h.GetProlificActorsHandler, log)
+	registerHandlerWithAuth(mux, "GET", "/stats/actors/ages", h.GetActorAgesHandler, log)
+	registerHandlerWithAuth(mux, "POST", "/admin/import", h.ImportHandler, log)
+	registerHandlerWithAuth(mux, "GET", "/admin/export", h.ExportHandler, log)
+	registerHandlerWithAuth(mux, "GET", "/admin/audit", h.GetAuditLogHandler, log)
+	registerHandlerWithAuth(mux, "GET", "/admin/trash/movies", h.ListDeletedMoviesHandler, log)
+	registerHandlerWithAuth(mux, "POST", "/admin/trash/movies/{id}/restore", h.RestoreMovieHandler, log)
+	registerHandlerWithAuth(mux, "DELETE", "/admin/trash/movies/{id}", h.PurgeMovieHandler, log)
+	registerHandlerWithAuth(mux, "GET", "/admin/trash/actors", h.ListDeletedActorsHandler, log)
+	registerHandlerWithAuth(mux, "POST", "/admin/trash/actors/{id}/restore", h.RestoreActorHandler, log)
+	registerHandlerWithAuth(mux, "DELETE", "/admin/trash/actors/{id}", h.PurgeActorHandler, log)
+	registerHandlerWithAuth(mux, "POST", "/genres", h.AddGenreHandler, log)
+	registerHandlerWithAuth(mux, "GET", "/genres", h.GetAllGenresHandler, log)
+	registerHandlerWithAuth(mux, "GET", "/genres/{id}", h.GetGenreHandler, log)
+	registerHandlerWithAuth(mux, "PUT", "/genres/{id}", h.UpdateGenreHandler, log)
+	registerHandlerWithAuth(mux, "DELETE", "/genres/{id}", h.DeleteGenreHandler, log)
 
 	// admin role must be given manually straight in db (task description), so there's no endpoint for that
 	mux.Handle("/register", middleware.Logging(http.HandlerFunc(h.RegisterHandler), log))
@@ -6,7 +6,13 @@ import (
 	"net/http"
 	"vk-backend/internal/api/router"
 	"vk-backend/internal/service/actor"
+	"vk-backend/internal/service/audit"
+	"vk-backend/internal/service/collection"
+	"vk-backend/internal/service/genre"
+	"vk-backend/internal/service/importer"
 	"vk-backend/internal/service/movie"
+	"vk-backend/internal/service/review"
+	"vk-backend/internal/service/stats"
 	"vk-backend/internal/service/user"
 )
 
@@ -14,8 +20,8 @@ type Server struct {
 	srv *http.Server
 }
 
-func New(addr string, actorSrv *actor.ActorService, movieSrv *movie.MovieService, user *user.UserService, log *logrus.Logger) *Server {
-	mux := router.New(actorSrv, movieSrv, user, log)
+func New(addr string, actorSrv *actor.ActorService, movieSrv *movie.MovieService, genreSrv *genre.GenreService, reviewSrv *review.ReviewService, collectionSrv *collection.CollectionService, statsSrv *stats.StatsService, importSrv *importer.ImportService, auditSrv *audit.AuditService, user *user.UserService, log *logrus.Logger) *Server {
+	mux := router.New(actorSrv, movieSrv, genreSrv, reviewSrv, collectionSrv, statsSrv, importSrv, auditSrv, user, log)
 	srv := &http.Server{
 		Addr:    ":" + addr,
 		Handler: mux,
@@ -7,4 +7,73 @@ type Actor struct {
 	Name      string
 	Gender    int // http://en.wikipedia.org/wiki/ISO_5218
 	BirthDate time.Time
+	Movies    []*Role // filmography, loaded only on request
+
+	// increased by every change, set by getting the actor. Updates apply only to this version
+	Version   int
+	CreatedAt time.Time
+	UpdatedAt time.Time // changed along with the version
+
+	MovieCount int // number of movies the actor is credited in, set only by listing
+
+	DeletedAt *time.Time // when the actor was moved to the trash, set only by listing the trash
+}
+
+// ActorFilter narrows down the list of actors, nil fields are not applied
+type ActorFilter struct {
+	Name          *string // substring of the name or a word similar to it
+	Gender        *int
+	BirthDateFrom *time.Time // born on or after
+	BirthDateTo   *time.Time // born on or before
+}
+
+type ActorSortField int
+
+const (
+	ActorSortByName ActorSortField = iota
+	ActorSortByBirthDate
+	ActorSortByMovieCount
+	ActorSortById // by insertion order, the order of actors without sort keys
+)
+
+type ActorSortKey struct {
+	Field ActorSortField
+	Desc  bool
+}
+
+// ActorSort lists keys actors are ordered by, the first key has the highest priority. Actors are listed
+// in insertion order when it is empty
+type ActorSort []ActorSortKey
+
+// CastMember is an actor credited in a movie
+type CastMember struct {
+	*Actor
+	Character string
+	Billing   int // position in the credits starting from 1 for the top-billed actor
+}
+
+// Role is a movie the actor is credited in
+type Role struct {
+	*Movie
+	Character string
+	Billing   int
+}
+
+// Costar is an actor who played in the same movies as another one
+type Costar struct {
+	*Actor
+	SharedMovies int
+}
+
+// CostarLink tells that two actors played in the movie together
+type CostarLink struct {
+	From    int
+	To      int
+	MovieId int
+}
+
+// ActorPath is a chain of actors, each of them played with the next one in the movie at the same position
+type ActorPath struct {
+	Actors []*Actor
+	Movies []*Movie // one less than actors
 }
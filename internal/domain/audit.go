@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditAction is the kind of change recorded in the audit log
+type AuditAction string
+
+const (
+	AuditCreate  AuditAction = "create"
+	AuditUpdate  AuditAction = "update"
+	AuditDelete  AuditAction = "delete"
+	AuditRestore AuditAction = "restore"
+	AuditPurge   AuditAction = "purge"
+)
+
+// AuditEntity is the kind of record changed, the cast is identified by the id of the movie
+type AuditEntity string
+
+const (
+	AuditActor AuditEntity = "actor"
+	AuditMovie AuditEntity = "movie"
+	AuditCast  AuditEntity = "cast"
+)
+
+func (e AuditEntity) Valid() bool {
+	return e == AuditActor || e == AuditMovie || e == AuditCast
+}
+
+// AuditEntry is a single change of the catalog made by an admin
+type AuditEntry struct {
+	Id       int
+	UserId   *int // nil when the change wasn't made through the API or the user is gone
+	Action   AuditAction
+	Entity   AuditEntity
+	EntityId int
+	Before   json.RawMessage // snapshot of the entity, nil when it didn't exist
+	After    json.RawMessage // nil when the entity doesn't exist anymore
+	At       time.Time
+}
+
+// AuditFilter narrows down the audit log, nil fields are not applied
+type AuditFilter struct {
+	UserId   *int
+	Entity   *AuditEntity
+	EntityId *int       // only along with Entity
+	From     *time.Time // changes made at or after
+	To       *time.Time // changes made before
+}
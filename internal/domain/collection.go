@@ -0,0 +1,19 @@
+package domain
+
+// Collection is a personal list of movies of a user
+type Collection string
+
+const (
+	Watchlist Collection = "watchlist"
+	Favorites Collection = "favorites"
+)
+
+func (c Collection) Valid() bool {
+	return c == Watchlist || c == Favorites
+}
+
+// UserCollection is the collection of the particular user
+type UserCollection struct {
+	UserId     int
+	Collection Collection
+}
@@ -17,7 +17,12 @@ var (
 
 	ErrInvalidRating       = errors.New("rating is invalid")
 	ErrActorAlreadyInMovie = errors.New("actor is already in the movie")
+	ErrActorNotInMovie     = errors.New("actor is not in the movie")
 	ErrEmptyReleaseDate    = errors.New("empty release date")
+	ErrTooLongCharacter    = errors.New("character name is too long")
+	ErrInvalidBilling      = errors.New("billing is invalid")
+	ErrBillingTaken        = errors.New("billing is taken by another actor")
+	ErrRepeatedCredit      = errors.New("actor or billing is repeated in the cast")
 
 	ErrUserAlreadyExists = errors.New("user already exists")
 	ErrUserNotExists     = errors.New("user does not exist")
@@ -26,4 +31,42 @@ var (
 	ErrEmptyPassword = errors.New("empty password")
 
 	ErrNotAdmin = errors.New("not admin")
+
+	ErrGenreNotExists     = errors.New("genre does not exist")
+	ErrGenreAlreadyExists = errors.New("genre already exists")
+	ErrRepeatedGenre      = errors.New("genre is repeated")
+	ErrTooLongName        = errors.New("name is too long")
+
+	ErrInvalidCursor = errors.New("invalid cursor")
+
+	ErrReviewNotExists = errors.New("review does not exist")
+	ErrTooLongReview   = errors.New("review is too long")
+	ErrNotAuthorized   = errors.New("not authorized")
+
+	ErrCollectionNotExists  = errors.New("collection does not exist")
+	ErrMovieNotInCollection = errors.New("movie is not in the collection")
+
+	ErrPathNotFound = errors.New("actors are not connected")
+	ErrInvalidDepth = errors.New("depth is invalid")
+
+	ErrInvalidBuckets = errors.New("number of buckets is invalid")
+
+	ErrNotInTrash = errors.New("record is not in the trash")
+
+	ErrInvalidAuditFilter = errors.New("invalid audit log filter")
+
+	ErrRevisionNotExists = errors.New("revision does not exist")
+
+	ErrVersionRequired = errors.New("version of the record is required")
+	ErrVersionMismatch = errors.New("record was changed since the given version")
+
+	ErrInvalidImportFormat = errors.New("import format is not supported")
+	ErrInvalidImportFile   = errors.New("import file is invalid")
+	ErrTooManyImportRows   = errors.New("too many rows to import")
+	ErrInvalidImportRow    = errors.New("row is invalid")
+	ErrAmbiguousActor      = errors.New("several actors have the same name and birth date")
+
+	ErrInvalidSort        = errors.New("invalid sort")
+	ErrInvalidDateRange   = errors.New("date range is invalid")
+	ErrInvalidRatingRange = errors.New("rating range is invalid")
 )
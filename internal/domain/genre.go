@@ -0,0 +1,6 @@
+package domain
+
+type Genre struct {
+	Id   int
+	Name string
+}
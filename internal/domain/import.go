@@ -0,0 +1,42 @@
+package domain
+
+// ImportReport tells how many records an import has written, or would write in a dry run,
+// and why the rest of the rows were skipped
+type ImportReport struct {
+	DryRun bool
+	Actors int
+	Movies int
+	Errors []ImportError
+}
+
+// ImportError is a skipped row, Line is counted from 1 including the header of CSV
+type ImportError struct {
+	Line int
+	Err  error
+}
+
+// ExternalMovie is a movie of an external dataset, it's matched with the stored one by ExternalId
+type ExternalMovie struct {
+	*Movie
+	ExternalId string
+}
+
+// ExternalActor is an actor of an external dataset, it's matched with the stored one by ExternalId
+type ExternalActor struct {
+	*Actor
+	ExternalId string
+}
+
+// ExternalCredit casts an actor in a movie, both are referred to by their external ids
+type ExternalCredit struct {
+	MovieId   string
+	ActorId   string
+	Character string
+	Billing   int
+}
+
+// Checkpoint tells how many lines of the dataset file are imported
+type Checkpoint struct {
+	Source string
+	Line   int64
+}
@@ -7,6 +7,61 @@ type Movie struct {
 	Title       string
 	Description string
 	ReleaseDate time.Time
-	Rating      float64
-	Actors      []*Actor
+	Rating      float64       // set by admins
+	Actors      []*CastMember // ordered by billing
+	Genres      []*Genre
+
+	Score       float64 // average rating given by users, Rating until the movie is rated
+	ReviewCount int
+
+	// increased by every change of the movie, its cast or genres, set by getting the movie.
+	// Updates apply only to this version.
+	Version int
+	// UpdatedAt is changed along with the version and by changes shown with the movie that don't change the version:
+	// the score and the names of the cast
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// set only by full-text search
+	Relevance float64
+	Headline  string // HTML of matched fragments of title and description, the text is escaped and matches are in <b> tags
+
+	AddedAt *time.Time // when the movie was added to the collection, set only by listing a collection
+
+	Similarity float64 // how close the movie is to another one, set only by recommendations
+
+	DeletedAt *time.Time // when the movie was moved to the trash, set only by listing the trash
 }
+
+// MovieFilter narrows down the list of movies, nil fields are not applied
+type MovieFilter struct {
+	Query           *string    // full-text search over title, description and names of actors
+	Title           *string    // substring of the title or of the name of any actor in the movie
+	ReleaseDateFrom *time.Time // released on or after
+	ReleaseDateTo   *time.Time // released on or before
+	RatingMin       *float64   // bounds of the score
+	RatingMax       *float64
+	Actor           *int            // movies the actor is credited in
+	Collection      *UserCollection // movies in the collection of the user
+	Genres          []int           // ids of genres the movie belongs to
+	AllGenres       bool            // movie must belong to all the genres instead of any of them
+	UpdatedSince    *time.Time      // changed at or after, for incremental sync
+}
+
+type MovieSortField int
+
+const (
+	MovieSortByRating MovieSortField = iota // by score
+	MovieSortByReleaseDate
+	MovieSortByTitle
+	MovieSortByRelevance
+	MovieSortByAdded // by the time movies were added to the collection
+)
+
+type MovieSortKey struct {
+	Field MovieSortField
+	Desc  bool
+}
+
+// MovieSort lists keys movies are ordered by, the first key has the highest priority
+type MovieSort []MovieSortKey
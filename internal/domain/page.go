@@ -0,0 +1,24 @@
+package domain
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// Page requests at most Limit items following the position encoded in Cursor,
+// empty cursor points to the beginning of the list
+type Page struct {
+	Limit  int
+	Cursor string
+}
+
+// Normalize replaces a missing limit with the default one and caps it at MaxPageLimit
+func (p Page) Normalize() Page {
+	if p.Limit <= 0 {
+		p.Limit = DefaultPageLimit
+	}
+	if p.Limit > MaxPageLimit {
+		p.Limit = MaxPageLimit
+	}
+	return p
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// Review is a rating a user gave to a movie, optionally with a text. A user reviews a movie once
+type Review struct {
+	Id        int
+	MovieId   int
+	UserId    int
+	Username  string
+	Rating    int // from 0 to 10
+	Text      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// Revision is a saved version of a movie or an actor, numbered from 1 in the order of updates
+type Revision struct {
+	Number int
+	UserId *int // nil for the state before the first update and when the user is gone
+	At     time.Time
+	Data   json.RawMessage // snapshot of the entity, not set by listing
+
+	// Data decoded, set only by getting a single revision
+	Movie *Movie // only the fields editors change, actors of the cast have only ids
+	Actor *Actor
+}
+
+// FieldChange is a field that differs between two revisions, nil value means there's no such field
+type FieldChange struct {
+	Field  string
+	Before json.RawMessage
+	After  json.RawMessage
+}
+
+// Diff returns fields changed from the revision to the other one ordered by name
+func (r *Revision) Diff(other *Revision) ([]FieldChange, error) {
+	before := map[string]json.RawMessage{}
+	if err := json.Unmarshal(r.Data, &before); err != nil {
+		return nil, err
+	}
+	after := map[string]json.RawMessage{}
+	if err := json.Unmarshal(other.Data, &after); err != nil {
+		return nil, err
+	}
+
+	fields := make([]string, 0, len(before))
+	for field := range before {
+		fields = append(fields, field)
+	}
+	for field := range after {
+		if _, ok := before[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	var changes []FieldChange
+	for _, field := range fields {
+		// snapshots are stored as jsonb, so equal values are encoded the same way
+		if !bytes.Equal(before[field], after[field]) {
+			changes = append(changes, FieldChange{Field: field, Before: before[field], After: after[field]})
+		}
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+// YearStats aggregates movies released in the same year
+type YearStats struct {
+	Year          int
+	MovieCount    int
+	AverageRating float64 // set by admins
+	AverageScore  float64 // aggregated from user reviews
+}
+
+// RatingBucket counts movies scored in [From, To), the last bucket includes 10
+type RatingBucket struct {
+	From       float64
+	To         float64
+	MovieCount int
+}
+
+// ProlificActor is an actor with the number of movies in MovieCount and the span of career
+type ProlificActor struct {
+	*Actor
+	FirstRelease time.Time
+	LastRelease  time.Time
+}
+
+// AgeCount tells in how many roles actors were of the age at the release
+type AgeCount struct {
+	Age   int
+	Roles int
+}
+
+// AgeBucket counts roles played at the age in [From, To)
+type AgeBucket struct {
+	From  int
+	To    int
+	Roles int
+}
+
+// AgeStats describes how old actors were when their movies were released
+type AgeStats struct {
+	Roles      int
+	AverageAge float64
+	Youngest   int
+	Oldest     int
+	Buckets    []AgeBucket
+}
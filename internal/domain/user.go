@@ -1,8 +1,23 @@
 package domain
 
+import "context"
+
 type User struct {
 	Id       int
 	Name     string
 	Password string
 	Role     string
 }
+
+type userIdKey struct{}
+
+// WithUserId returns a copy of the context carrying id of the user making the request
+func WithUserId(ctx context.Context, id int) context.Context {
+	return context.WithValue(ctx, userIdKey{}, id)
+}
+
+// UserIdFrom returns id of the user making the request, there is none for anonymous requests and changes made outside the API
+func UserIdFrom(ctx context.Context) (int, bool) {
+	id, ok := ctx.Value(userIdKey{}).(int)
+	return id, ok
+}
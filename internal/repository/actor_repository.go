@@ -13,9 +13,21 @@ type ActorRepository interface {
 	AddActor(ctx context.Context, name string, gender int, birthDate time.Time) (*domain.Actor, error)
 	GetActorById(ctx context.Context, id int) (*domain.Actor, error)
 
-	ListActors(ctx context.Context) ([]*domain.Actor, error)
+	ListActors(ctx context.Context, filter domain.ActorFilter, sort domain.ActorSort, page domain.Page) ([]*domain.Actor, string, error)
+	LoadFilmographies(ctx context.Context, actors []*domain.Actor) error
+
+	ListCostars(ctx context.Context, actorId int, page domain.Page) ([]*domain.Costar, string, error)
+	ExpandCostars(ctx context.Context, actorIds []int) ([]domain.CostarLink, error)
+	GetActorsByIds(ctx context.Context, ids []int) (map[int]*domain.Actor, error)
+	GetMoviesByIds(ctx context.Context, ids []int) (map[int]*domain.Movie, error)
 	UpdateActor(ctx context.Context, new *domain.Actor) error
-	DeleteActor(ctx context.Context, id int) error
+	DeleteActor(ctx context.Context, id int, version int) error
+	ListDeletedActors(ctx context.Context, page domain.Page) ([]*domain.Actor, string, error)
+	RestoreActor(ctx context.Context, id int) (bool, error)
+	PurgeActor(ctx context.Context, id int) (bool, error)
+	ListRevisions(ctx context.Context, entity domain.AuditEntity, id int, page domain.Page) ([]*domain.Revision, string, error)
+	GetRevision(ctx context.Context, entity domain.AuditEntity, id int, number int) (*domain.Revision, error)
+	ExportActors(ctx context.Context, fn func(*domain.Actor) error) error
 
 	ActorExists(ctx context.Context, id int) (bool, error)
 }
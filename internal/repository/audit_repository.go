@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+	"vk-backend/internal/domain"
+	"vk-backend/internal/repository/queries"
+)
+
+type AuditRepository interface {
+	ListAuditLog(ctx context.Context, filter domain.AuditFilter, page domain.Page) ([]*domain.AuditEntry, string, error)
+}
+
+type auditRepo struct {
+	*queries.Queries
+	pool   *pgxpool.Pool
+	logger logrus.FieldLogger
+}
+
+func NewAuditRepository(pool *pgxpool.Pool, logger logrus.FieldLogger) AuditRepository {
+	return &auditRepo{
+		Queries: queries.NewQueries(pool),
+		pool:    pool,
+		logger:  logger,
+	}
+}
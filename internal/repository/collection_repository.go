@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+	"vk-backend/internal/domain"
+	"vk-backend/internal/repository/queries"
+)
+
+type CollectionRepository interface {
+	AddToCollection(ctx context.Context, c domain.UserCollection, movieId int) error
+	RemoveFromCollection(ctx context.Context, c domain.UserCollection, movieId int) (bool, error)
+	InCollection(ctx context.Context, c domain.UserCollection, movieIds []int) (map[int]bool, error)
+
+	MovieExists(ctx context.Context, id int) (bool, error)
+}
+
+type collectionRepo struct {
+	*queries.Queries
+	pool   *pgxpool.Pool
+	logger logrus.FieldLogger
+}
+
+func NewCollectionRepository(pool *pgxpool.Pool, logger logrus.FieldLogger) CollectionRepository {
+	return &collectionRepo{
+		Queries: queries.NewQueries(pool),
+		pool:    pool,
+		logger:  logger,
+	}
+}
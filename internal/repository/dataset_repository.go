@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+	"vk-backend/internal/domain"
+	"vk-backend/internal/repository/queries"
+)
+
+type DatasetRepository interface {
+	UpsertExternalMovies(ctx context.Context, movies []*domain.ExternalMovie, cp domain.Checkpoint) (int, error)
+	UpsertExternalActors(ctx context.Context, actors []*domain.ExternalActor, cp domain.Checkpoint) (int, error)
+	UpsertExternalCredits(ctx context.Context, credits []*domain.ExternalCredit, cp domain.Checkpoint) (int, error)
+
+	GetCheckpoint(ctx context.Context, source string) (int64, error)
+	ResetCheckpoints(ctx context.Context, sources []string) error
+}
+
+type datasetRepo struct {
+	*queries.Queries
+	pool   *pgxpool.Pool
+	logger logrus.FieldLogger
+}
+
+func NewDatasetRepository(pool *pgxpool.Pool, logger logrus.FieldLogger) DatasetRepository {
+	return &datasetRepo{
+		Queries: queries.NewQueries(pool),
+		pool:    pool,
+		logger:  logger,
+	}
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+	"vk-backend/internal/domain"
+	"vk-backend/internal/repository/queries"
+)
+
+type GenreRepository interface {
+	AddGenre(ctx context.Context, name string) (*domain.Genre, error)
+	GetGenreById(ctx context.Context, id int) (*domain.Genre, error)
+	ListGenres(ctx context.Context) ([]*domain.Genre, error)
+	UpdateGenre(ctx context.Context, new *domain.Genre) error
+	DeleteGenre(ctx context.Context, id int) error
+
+	GenreExists(ctx context.Context, id int) (bool, error)
+	GenreNameExists(ctx context.Context, name string) (bool, error)
+}
+
+type genreRepo struct {
+	*queries.Queries
+	pool   *pgxpool.Pool
+	logger logrus.FieldLogger
+}
+
+func NewGenreRepository(pool *pgxpool.Pool, logger logrus.FieldLogger) GenreRepository {
+	return &genreRepo{
+		Queries: queries.NewQueries(pool),
+		pool:    pool,
+		logger:  logger,
+	}
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+	"vk-backend/internal/domain"
+	"vk-backend/internal/repository/queries"
+)
+
+type ImportRepository interface {
+	ImportCatalog(ctx context.Context, actors []*domain.Actor, movies []*domain.Movie) error
+
+	GetActorsByIds(ctx context.Context, ids []int) (map[int]*domain.Actor, error)
+	FindActorsByNameAndBirthDate(ctx context.Context, actors []*domain.Actor) ([]*domain.Actor, error)
+}
+
+type importRepo struct {
+	*queries.Queries
+	pool   *pgxpool.Pool
+	logger logrus.FieldLogger
+}
+
+func NewImportRepository(pool *pgxpool.Pool, logger logrus.FieldLogger) ImportRepository {
+	return &importRepo{
+		Queries: queries.NewQueries(pool),
+		pool:    pool,
+		logger:  logger,
+	}
+}
@@ -10,13 +10,23 @@ import (
 )
 
 type MovieRepository interface {
-	AddMovie(ctx context.Context, title string, description string, releaseDate time.Time, rating float64, actors []*domain.Actor) (*domain.Movie, error)
-	AddActorToMovie(ctx context.Context, actorId int, movieId int) error
+	AddMovie(ctx context.Context, title string, description string, releaseDate time.Time, rating float64, actors []*domain.CastMember, genres []*domain.Genre) (*domain.Movie, error)
+	AddActorToMovie(ctx context.Context, actorId int, movieId int, character string, billing int, version int) (int, error)
+	RemoveActorFromMovie(ctx context.Context, actorId int, movieId int, version int) (int, error)
+	ReplaceCast(ctx context.Context, movieId int, cast []*domain.CastMember, version int) (int, error)
 	GetMovieById(ctx context.Context, id int) (*domain.Movie, error)
 	GetActorsByMovieId(ctx context.Context, movieId int) ([]*domain.Actor, error)
-	ListMovies(ctx context.Context) ([]*domain.Movie, error)
+	ListMovies(ctx context.Context, filter domain.MovieFilter, sort domain.MovieSort, page domain.Page) ([]*domain.Movie, string, error)
 	UpdateMovie(ctx context.Context, new *domain.Movie) error
-	DeleteMovie(ctx context.Context, id int) error
+	DeleteMovie(ctx context.Context, id int, version int) error
+	ListDeletedMovies(ctx context.Context, page domain.Page) ([]*domain.Movie, string, error)
+	RestoreMovie(ctx context.Context, id int) (bool, error)
+	PurgeMovie(ctx context.Context, id int) (bool, error)
+	ListRevisions(ctx context.Context, entity domain.AuditEntity, id int, page domain.Page) ([]*domain.Revision, string, error)
+	GetRevision(ctx context.Context, entity domain.AuditEntity, id int, number int) (*domain.Revision, error)
+	SuggestSpellings(ctx context.Context, term string, limit int) ([]string, error)
+	SimilarMovies(ctx context.Context, movieId int, limit int) ([]*domain.Movie, error)
+	ExportMovies(ctx context.Context, fn func(*domain.Movie) error) error
 
 	ActorExists(ctx context.Context, id int) (bool, error)
 	MovieExists(ctx context.Context, id int) (bool, error)
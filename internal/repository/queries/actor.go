@@ -2,45 +2,83 @@ package queries
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/jackc/pgx/v5"
+	"strconv"
+	"strings"
 	"time"
 	"vk-backend/internal/domain"
 )
 
-const insertActorQuery = `INSERT INTO actors (name, gender, birth_date) VALUES ($1, $2, $3) RETURNING id`
+const insertActorQuery = `INSERT INTO actors (name, gender, birth_date) VALUES ($1, $2, $3) RETURNING id, version, created_at, updated_at`
 
 func (q *Queries) AddActor(ctx context.Context, name string, gender int, birthDate time.Time) (*domain.Actor, error) {
-	row := q.pool.QueryRow(ctx, insertActorQuery, name, gender, birthDate)
+	tx, err := q.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	row := tx.QueryRow(ctx, insertActorQuery, name, gender, birthDate)
 
 	actor := &domain.Actor{
 		Name:      name,
 		Gender:    gender,
 		BirthDate: birthDate,
 	}
-	if err := row.Scan(&actor.Id); err != nil {
+	if err := row.Scan(&actor.Id, &actor.Version, &actor.CreatedAt, &actor.UpdatedAt); err != nil {
+		_ = tx.Rollback(ctx)
 		return nil, fmt.Errorf("failed to insert actor: %w", err)
 	}
 
+	if err := writeAudit(ctx, tx, domain.AuditCreate, domain.AuditActor, actor.Id, nil); err != nil {
+		_ = tx.Rollback(ctx)
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return actor, nil
 }
 
-const insertActorToMovieQuery = `INSERT INTO movie_actors (actor_id, movie_id) VALUES ($1, $2)`
+// insertActorToMovieQuery bills the actor after the rest of the cast when billing is 0,
+// credits of actors in the trash aren't in the cast and are billed after it by billTrashedCredits
+const insertActorToMovieQuery = `
+INSERT INTO movie_actors (actor_id, movie_id, character_name, billing)
+VALUES ($1, $2, $3, COALESCE(NULLIF($4, 0), (
+    SELECT COALESCE(MAX(ma.billing), 0) + 1
+    FROM movie_actors ma JOIN actors a ON a.id = ma.actor_id AND a.deleted_at IS NULL
+    WHERE ma.movie_id = $2
+)))
+`
 
-func (q *Queries) AddActorToMovie(ctx context.Context, actorId int, movieId int) error {
-	if _, err := q.pool.Exec(ctx, insertActorToMovieQuery, actorId, movieId); err != nil {
-		return fmt.Errorf("failed to insert actor to movie: %w", err)
-	}
+// AddActorToMovie credits the actor in the movie of the given version and returns the new version of the movie
+func (q *Queries) AddActorToMovie(ctx context.Context, actorId int, movieId int, character string, billing int, version int) (int, error) {
+	_, err := q.audited(ctx, domain.AuditUpdate, domain.AuditCast, movieId, versioned(ctx, domain.AuditMovie, movieId, version, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, insertActorToMovieQuery, actorId, movieId, character, billing); err != nil {
+			if isUniqueViolation(err) {
+				return domain.ErrActorAlreadyInMovie
+			}
+			return fmt.Errorf("failed to insert actor to movie: %w", err)
+		}
+		if err := billTrashedCredits(ctx, tx, movieId); err != nil {
+			return err
+		}
+		return bumpMovieVersion(ctx, tx, movieId, &version)
+	}))
 
-	return nil
+	return version, err
 }
 
-const selectActorQuery = `SELECT name, gender, birth_date FROM actors WHERE id = $1`
+const selectActorQuery = `SELECT name, gender, birth_date, version, created_at, updated_at FROM actors WHERE id = $1 AND deleted_at IS NULL`
 
 func (q *Queries) GetActorById(ctx context.Context, id int) (*domain.Actor, error) {
 	row := q.pool.QueryRow(ctx, selectActorQuery, id)
 
 	actor := &domain.Actor{Id: id}
-	if err := row.Scan(&actor.Name, &actor.Gender, &actor.BirthDate); err != nil {
+	if err := row.Scan(&actor.Name, &actor.Gender, &actor.BirthDate, &actor.Version, &actor.CreatedAt, &actor.UpdatedAt); err != nil {
 		return nil, fmt.Errorf("failed to get actor: %w", err)
 	}
 
@@ -51,7 +89,8 @@ const selectActorsByMovieIdQuery = `
 SELECT actors.id, actors.name, actors.gender, actors.birth_date
 FROM actors
 JOIN movie_actors ON actors.id = movie_actors.actor_id
-WHERE movie_actors.movie_id = $1
+WHERE movie_actors.movie_id = $1 AND actors.deleted_at IS NULL
+ORDER BY movie_actors.billing, movie_actors.id
 `
 
 func (q *Queries) GetActorsByMovieId(ctx context.Context, movieId int) ([]*domain.Actor, error) {
@@ -65,7 +104,7 @@ func (q *Queries) GetActorsByMovieId(ctx context.Context, movieId int) ([]*domai
 	var actors []*domain.Actor
 	for rows.Next() {
 		actor := &domain.Actor{}
-		if err := rows.Scan(ctx, selectActorsByMovieIdQuery, &actor.Id, &actor.Name, &actor.Gender, &actor.BirthDate); err != nil {
+		if err := rows.Scan(&actor.Id, &actor.Name, &actor.Gender, &actor.BirthDate); err != nil {
 			return nil, fmt.Errorf("failed to get actors by movie id: %w", err)
 		}
 		actors = append(actors, actor)
@@ -77,52 +116,248 @@ func (q *Queries) GetActorsByMovieId(ctx context.Context, movieId int) ([]*domai
 	return actors, nil
 }
 
-const selectAllActorsQuery = `SELECT id, name, gender, birth_date FROM actors`
+const listActorsQuery = `
+SELECT a.id, a.name, a.gender, a.birth_date, a.created_at, a.updated_at, mc.movie_count
+FROM actors a
+CROSS JOIN LATERAL (SELECT count(*)::int AS movie_count
+                    FROM movie_actors ma
+                    JOIN movies m ON m.id = ma.movie_id AND m.deleted_at IS NULL
+                    WHERE ma.actor_id = a.id) mc
+`
+
+var (
+	actorIdKey = sortKey[*domain.Actor]{
+		expr:  "a.id",
+		typ:   "int",
+		value: func(a *domain.Actor) string { return strconv.Itoa(a.Id) },
+	}
+
+	// actorSortKeys are ascending, names are compared bytewise, the same way Go compares strings
+	actorSortKeys = map[domain.ActorSortField]sortKey[*domain.Actor]{
+		domain.ActorSortByName: {
+			expr:  `a.name COLLATE "C"`,
+			typ:   "text",
+			value: func(a *domain.Actor) string { return a.Name },
+		},
+		domain.ActorSortByBirthDate: {
+			expr:  "a.birth_date",
+			typ:   "date",
+			value: func(a *domain.Actor) string { return a.BirthDate.Format(time.DateOnly) },
+		},
+		domain.ActorSortByMovieCount: {
+			expr:  "mc.movie_count",
+			typ:   "int",
+			value: func(a *domain.Actor) string { return strconv.Itoa(a.MovieCount) },
+		},
+		domain.ActorSortById: actorIdKey,
+	}
+)
+
+// actorOrder makes a total order out of the sort keys by adding id as the last one unless they are sorted by id,
+// without sort keys actors are listed in insertion order
+func actorOrder(sort domain.ActorSort) (keyset[*domain.Actor], error) {
+	order := keyset[*domain.Actor]{name: "id", keys: make([]sortKey[*domain.Actor], 0, len(sort)+1)}
+	names := make([]string, 0, len(sort))
+	byId := false
+	for _, k := range sort {
+		key, ok := actorSortKeys[k.Field]
+		if !ok {
+			return order, domain.ErrInvalidSort
+		}
+		key.desc = k.Desc
+		order.keys = append(order.keys, key)
+		names = append(names, fmt.Sprintf("%d:%t", k.Field, k.Desc))
+		byId = byId || k.Field == domain.ActorSortById
+	}
+	if !byId {
+		order.keys = append(order.keys, actorIdKey)
+	}
+	if len(names) > 0 {
+		order.name = strings.Join(names, ",")
+	}
+
+	return order, nil
+}
+
+func buildListActorsQuery(filter domain.ActorFilter, order keyset[*domain.Actor], page domain.Page) (string, []any, error) {
+	b := &queryBuilder{}
+	b.where("a.deleted_at IS NULL")
+	if filter.Name != nil {
+		// substring or a word similar to the term, so misspelled names are still found
+		pattern, term := b.arg(containsPattern(*filter.Name)), b.arg(*filter.Name)
+		b.where(fmt.Sprintf("(a.name ILIKE %s OR %s <%% a.name)", pattern, term))
+	}
+	if filter.Gender != nil {
+		b.where("a.gender = " + b.arg(*filter.Gender))
+	}
+	if filter.BirthDateFrom != nil {
+		b.where("a.birth_date >= " + b.arg(*filter.BirthDateFrom))
+	}
+	if filter.BirthDateTo != nil {
+		b.where("a.birth_date <= " + b.arg(*filter.BirthDateTo))
+	}
+	if err := order.after(b, page.Cursor); err != nil {
+		return "", nil, err
+	}
+
+	// one extra row tells if there is a next page
+	limit := b.arg(page.Limit + 1)
+
+	return fmt.Sprintf("%s %s ORDER BY %s LIMIT %s", listActorsQuery, b.whereClause(), order.orderBy(), limit), b.args, nil
+}
+
+// ListActors returns a page of actors matching the filter and a cursor of the next page, which is empty for the last one
+func (q *Queries) ListActors(ctx context.Context, filter domain.ActorFilter, sort domain.ActorSort, page domain.Page) ([]*domain.Actor, string, error) {
+	order, err := actorOrder(sort)
+	if err != nil {
+		return nil, "", err
+	}
+	query, args, err := buildListActorsQuery(filter, order, page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var actors []*domain.Actor
+	list := func(db querier) error {
+		rows, err := db.Query(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to list actors: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			actor := &domain.Actor{}
+			if err := rows.Scan(&actor.Id, &actor.Name, &actor.Gender, &actor.BirthDate, &actor.CreatedAt, &actor.UpdatedAt, &actor.MovieCount); err != nil {
+				return fmt.Errorf("failed to list actors: %w", err)
+			}
+			actors = append(actors, actor)
+		}
+		if rows.Err() != nil {
+			return fmt.Errorf("failed to list actors: %w", rows.Err())
+		}
+		return nil
+	}
 
-func (q *Queries) ListActors(ctx context.Context) ([]*domain.Actor, error) {
-	rows, err := q.pool.Query(ctx, selectAllActorsQuery)
+	if filter.Name != nil {
+		err = q.withWordSimilarityThreshold(ctx, fuzzyMatchThreshold, func(tx pgx.Tx) error { return list(tx) })
+	} else {
+		err = list(q.pool)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to select all actors: %w", err)
+		return nil, "", err
 	}
 
+	actors, next := paginate(actors, page.Limit, order)
+
+	return actors, next, nil
+}
+
+const getFilmographiesQuery = `
+SELECT ma.actor_id, m.id, m.title, m.description, m.release_date, m.rating, m.score, m.review_count, ma.character_name, ma.billing
+FROM movie_actors ma
+JOIN movies m ON m.id = ma.movie_id
+WHERE ma.actor_id = ANY($1) AND m.deleted_at IS NULL
+ORDER BY ma.actor_id, m.release_date DESC, m.id
+`
+
+// LoadFilmographies fills movies of all the given actors with a single query, the newest movies go first
+func (q *Queries) LoadFilmographies(ctx context.Context, actors []*domain.Actor) error {
+	if len(actors) == 0 {
+		return nil
+	}
+
+	byId := make(map[int]*domain.Actor, len(actors))
+	ids := make([]int, 0, len(actors))
+	for _, actor := range actors {
+		byId[actor.Id] = actor
+		ids = append(ids, actor.Id)
+	}
+
+	rows, err := q.pool.Query(ctx, getFilmographiesQuery, ids)
+	if err != nil {
+		return fmt.Errorf("failed to select filmographies: %w", err)
+	}
 	defer rows.Close()
 
-	var actors []*domain.Actor
 	for rows.Next() {
-		actor := &domain.Actor{}
-		if err := rows.Scan(&actor.Id, &actor.Name, &actor.Gender, &actor.BirthDate); err != nil {
-			return nil, fmt.Errorf("failed to list all the actors: %w", err)
+		var actorId int
+		role := &domain.Role{Movie: &domain.Movie{}}
+		if err := rows.Scan(&actorId, &role.Id, &role.Title, &role.Description, &role.ReleaseDate, &role.Rating, &role.Score, &role.ReviewCount, &role.Character, &role.Billing); err != nil {
+			return fmt.Errorf("failed to scan filmography: %w", err)
 		}
-		actors = append(actors, actor)
+		byId[actorId].Movies = append(byId[actorId].Movies, role)
 	}
 	if rows.Err() != nil {
-		return nil, fmt.Errorf("failed to list all the actors: %w", rows.Err())
+		return fmt.Errorf("failed to select filmographies: %w", rows.Err())
 	}
 
-	return actors, nil
+	return nil
 }
 
-const updateActorQuery = `UPDATE actors SET name = $2, gender = $3, birth_date = $4 WHERE id = $1`
+const updateActorQuery = `
+UPDATE actors SET name = $2, gender = $3, birth_date = $4, version = version + 1, updated_at = now()
+WHERE id = $1 AND deleted_at IS NULL AND version = $5
+RETURNING version
+`
 
-func (q *Queries) UpdateActor(ctx context.Context, new *domain.Actor) error {
-	if _, err := q.pool.Exec(ctx, updateActorQuery, new.Id, new.Name, new.Gender, new.BirthDate); err != nil {
-		return fmt.Errorf("failed to update actor: %w", err)
-	}
+// movies show the names of their cast, so changes of actors change the movies as well,
+// the version of the movies is left as it is, since their own data didn't change
+const touchActorMoviesQuery = `
+UPDATE movies SET updated_at = now()
+WHERE id IN (SELECT movie_id FROM movie_actors WHERE actor_id = $1) AND deleted_at IS NULL
+`
 
+func touchActorMovies(ctx context.Context, tx pgx.Tx, actorId int) error {
+	if _, err := tx.Exec(ctx, touchActorMoviesQuery, actorId); err != nil {
+		return fmt.Errorf("failed to update movies of actor: %w", err)
+	}
 	return nil
 }
 
-const deleteActorQuery = `DELETE FROM actors WHERE id = $1`
+// UpdateActor overwrites the actor of the given version and sets the new one, the new state is saved as a revision.
+// It fails with domain.ErrVersionMismatch if the actor was changed since that version.
+func (q *Queries) UpdateActor(ctx context.Context, new *domain.Actor) error {
+	_, err := q.audited(ctx, domain.AuditUpdate, domain.AuditActor, new.Id, versioned(ctx, domain.AuditActor, new.Id, new.Version, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, updateActorQuery, new.Id, new.Name, new.Gender, new.BirthDate, new.Version).Scan(&new.Version)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrVersionMismatch
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update actor: %w", err)
+		}
+		return touchActorMovies(ctx, tx, new.Id)
+	}))
+
+	return err
+}
+
+// actors are moved to the trash, their credits are kept for the restore
+const deleteActorQuery = `
+UPDATE actors SET deleted_at = now(), version = version + 1, updated_at = now()
+WHERE id = $1 AND deleted_at IS NULL AND version = $2
+`
 
-func (q *Queries) DeleteActor(ctx context.Context, id int) error {
-	if _, err := q.pool.Exec(ctx, deleteActorQuery, id); err != nil {
-		return fmt.Errorf("failed to delete actor: %w", err)
-	}
+// DeleteActor moves the actor of the given version to the trash,
+// it fails with domain.ErrVersionMismatch if the actor was changed since that version
+func (q *Queries) DeleteActor(ctx context.Context, id int, version int) error {
+	_, err := q.audited(ctx, domain.AuditDelete, domain.AuditActor, id, func(tx pgx.Tx) (bool, error) {
+		if err := lockVersion(ctx, tx, domain.AuditActor, id, version); err != nil {
+			return false, err
+		}
+		tag, err := tx.Exec(ctx, deleteActorQuery, id, version)
+		if err != nil {
+			return false, fmt.Errorf("failed to delete actor: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return false, domain.ErrVersionMismatch
+		}
+		return true, touchActorMovies(ctx, tx, id)
+	})
 
-	return nil
+	return err
 }
 
-const existsActorQuery = `SELECT EXISTS(SELECT 1 FROM actors WHERE id = $1)`
+const existsActorQuery = `SELECT EXISTS(SELECT 1 FROM actors WHERE id = $1 AND deleted_at IS NULL)`
 
 func (q *Queries) ActorExists(ctx context.Context, id int) (bool, error) {
 	var exists bool
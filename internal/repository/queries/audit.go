@@ -0,0 +1,217 @@
+package queries
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"strconv"
+	"vk-backend/internal/domain"
+)
+
+// auditSnapshot selects the entity as JSON, the expression refers to the row of the table
+type auditSnapshot struct {
+	table string
+	id    string // column identifying the entity
+	expr  string
+}
+
+// the cast is captured as the API shows it, credits of actors in the trash are kept aside until the restore
+const castSnapshotExpr = `(SELECT coalesce(jsonb_agg(jsonb_build_object('actor_id', ma.actor_id, 'character', ma.character_name, 'billing', ma.billing) ORDER BY ma.billing, ma.id), '[]')
+FROM movie_actors ma
+JOIN actors a ON a.id = ma.actor_id AND a.deleted_at IS NULL
+WHERE ma.movie_id = m.id)`
+
+// snapshots keep what admins can change, deleted rows are captured too, so the trash is audited as well
+var auditSnapshots = map[domain.AuditEntity]auditSnapshot{
+	domain.AuditActor: {
+		table: "actors a",
+		id:    "a.id",
+		expr:  `jsonb_build_object('name', a.name, 'gender', a.gender, 'birth_date', a.birth_date, 'deleted_at', a.deleted_at)`,
+	},
+	domain.AuditMovie: {
+		table: "movies m",
+		id:    "m.id",
+		expr: `jsonb_build_object('title', m.title, 'description', m.description, 'release_date', m.release_date, 'rating', m.rating, 'deleted_at', m.deleted_at,
+'genres', (SELECT coalesce(jsonb_agg(mg.genre_id ORDER BY mg.genre_id), '[]') FROM movie_genres mg WHERE mg.movie_id = m.id),
+'cast', ` + castSnapshotExpr + `)`,
+	},
+	domain.AuditCast: {
+		table: "movies m",
+		id:    "m.id",
+		expr:  castSnapshotExpr,
+	},
+}
+
+// auditUserId returns id of the user making the change, changes made outside the API are recorded without a user
+func auditUserId(ctx context.Context) *int {
+	id, ok := domain.UserIdFrom(ctx)
+	if !ok {
+		return nil
+	}
+	return &id
+}
+
+// snapshot returns the entity as JSON or nil if it doesn't exist
+func snapshot(ctx context.Context, tx pgx.Tx, entity domain.AuditEntity, id int) ([]byte, error) {
+	s := auditSnapshots[entity]
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s = $1", s.expr, s.table, s.id)
+
+	var data []byte
+	if err := tx.QueryRow(ctx, query, id).Scan(&data); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to take %s snapshot: %w", entity, err)
+	}
+
+	return data, nil
+}
+
+const insertAuditQuery = `
+INSERT INTO audit_log (user_id, action, entity, entity_id, before, after)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+// writeAudit records the change of the entity made in the transaction, before is the snapshot taken prior to the change
+func writeAudit(ctx context.Context, tx pgx.Tx, action domain.AuditAction, entity domain.AuditEntity, id int, before []byte) error {
+	after, err := snapshot(ctx, tx, entity, id)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, insertAuditQuery, auditUserId(ctx), string(action), string(entity), id, before, after); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+
+	return nil
+}
+
+const insertCreatedAuditQuery = `
+INSERT INTO audit_log (user_id, action, entity, entity_id, after)
+SELECT $1, 'create', $2, %[1]s, %[2]s FROM %[3]s WHERE %[1]s = ANY($3)
+`
+
+// writeCreatedAudit records creation of many entities of the same kind at once
+func writeCreatedAudit(ctx context.Context, tx pgx.Tx, entity domain.AuditEntity, ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	s := auditSnapshots[entity]
+	query := fmt.Sprintf(insertCreatedAuditQuery, s.id, s.expr, s.table)
+
+	if _, err := tx.Exec(ctx, query, auditUserId(ctx), string(entity), ids); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+
+	return nil
+}
+
+// audited runs the change in a transaction and records it in the audit log along with snapshots
+// of the entity before and after it. Nothing is recorded when the change reports that it changed nothing.
+func (q *Queries) audited(
+	ctx context.Context,
+	action domain.AuditAction,
+	entity domain.AuditEntity,
+	id int,
+	change func(tx pgx.Tx) (bool, error),
+) (bool, error) {
+	tx, err := q.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	before, err := snapshot(ctx, tx, entity, id)
+	if err != nil {
+		_ = tx.Rollback(ctx)
+		return false, err
+	}
+
+	changed, err := change(tx)
+	if err != nil || !changed {
+		_ = tx.Rollback(ctx)
+		return false, err
+	}
+
+	if err := writeAudit(ctx, tx, action, entity, id, before); err != nil {
+		_ = tx.Rollback(ctx)
+		return false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return true, nil
+}
+
+const listAuditQuery = `
+SELECT l.id, l.user_id, l.action, l.entity, l.entity_id, l.before, l.after, l.created_at
+FROM audit_log l
+`
+
+// auditOrder lists the latest changes first
+var auditOrder = keyset[*domain.AuditEntry]{name: "audit", keys: []sortKey[*domain.AuditEntry]{{
+	expr:  "l.id",
+	typ:   "bigint",
+	desc:  true,
+	value: func(e *domain.AuditEntry) string { return strconv.Itoa(e.Id) },
+}}}
+
+func buildListAuditQuery(filter domain.AuditFilter, page domain.Page) (string, []any, error) {
+	b := &queryBuilder{}
+	if filter.UserId != nil {
+		b.where("l.user_id = " + b.arg(*filter.UserId))
+	}
+	if filter.Entity != nil {
+		b.where("l.entity = " + b.arg(string(*filter.Entity)))
+	}
+	if filter.EntityId != nil {
+		b.where("l.entity_id = " + b.arg(*filter.EntityId))
+	}
+	if filter.From != nil {
+		b.where("l.created_at >= " + b.arg(*filter.From))
+	}
+	if filter.To != nil {
+		b.where("l.created_at < " + b.arg(*filter.To))
+	}
+	if err := auditOrder.after(b, page.Cursor); err != nil {
+		return "", nil, err
+	}
+
+	query := fmt.Sprintf("%s %s ORDER BY %s LIMIT %s", listAuditQuery, b.whereClause(), auditOrder.orderBy(), b.arg(page.Limit+1))
+	return query, b.args, nil
+}
+
+// ListAuditLog returns a page of changes matching the filter and a cursor of the next page, which is empty for the last one
+func (q *Queries) ListAuditLog(ctx context.Context, filter domain.AuditFilter, page domain.Page) ([]*domain.AuditEntry, string, error) {
+	query, args, err := buildListAuditQuery(filter, page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	rows, err := q.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list audit log: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*domain.AuditEntry
+	for rows.Next() {
+		var action, entity string
+		entry := &domain.AuditEntry{}
+		if err := rows.Scan(&entry.Id, &entry.UserId, &action, &entity, &entry.EntityId, &entry.Before, &entry.After, &entry.At); err != nil {
+			return nil, "", fmt.Errorf("failed to list audit log: %w", err)
+		}
+		entry.Action = domain.AuditAction(action)
+		entry.Entity = domain.AuditEntity(entity)
+		entries = append(entries, entry)
+	}
+	if rows.Err() != nil {
+		return nil, "", fmt.Errorf("failed to list audit log: %w", rows.Err())
+	}
+
+	entries, next := paginate(entries, page.Limit, auditOrder)
+
+	return entries, next, nil
+}
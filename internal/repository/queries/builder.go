@@ -0,0 +1,36 @@
+package queries
+
+import (
+	"strconv"
+	"strings"
+)
+
+// queryBuilder collects conditions of a dynamic WHERE clause together with their positional arguments
+type queryBuilder struct {
+	conds []string
+	args  []any
+}
+
+// arg registers a value and returns its placeholder
+func (b *queryBuilder) arg(v any) string {
+	b.args = append(b.args, v)
+	return "$" + strconv.Itoa(len(b.args))
+}
+
+func (b *queryBuilder) where(cond string) {
+	b.conds = append(b.conds, cond)
+}
+
+func (b *queryBuilder) whereClause() string {
+	if len(b.conds) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(b.conds, " AND ")
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// containsPattern returns ILIKE pattern matching any string that contains s
+func containsPattern(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
@@ -0,0 +1,63 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"vk-backend/internal/domain"
+)
+
+// addToCollectionQuery keeps the original time when the movie is added again
+const addToCollectionQuery = `
+INSERT INTO user_movies (user_id, collection, movie_id)
+VALUES ($1, $2, $3)
+ON CONFLICT DO NOTHING
+`
+
+func (q *Queries) AddToCollection(ctx context.Context, c domain.UserCollection, movieId int) error {
+	if _, err := q.pool.Exec(ctx, addToCollectionQuery, c.UserId, string(c.Collection), movieId); err != nil {
+		return fmt.Errorf("failed to add movie to collection: %w", err)
+	}
+
+	return nil
+}
+
+const removeFromCollectionQuery = `DELETE FROM user_movies WHERE user_id = $1 AND collection = $2 AND movie_id = $3`
+
+// RemoveFromCollection returns false if the movie was not in the collection
+func (q *Queries) RemoveFromCollection(ctx context.Context, c domain.UserCollection, movieId int) (bool, error) {
+	tag, err := q.pool.Exec(ctx, removeFromCollectionQuery, c.UserId, string(c.Collection), movieId)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove movie from collection: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+const inCollectionQuery = `SELECT movie_id FROM user_movies WHERE user_id = $1 AND collection = $2 AND movie_id = ANY($3)`
+
+// InCollection tells which of the movies are in the collection with a single query
+func (q *Queries) InCollection(ctx context.Context, c domain.UserCollection, movieIds []int) (map[int]bool, error) {
+	in := make(map[int]bool, len(movieIds))
+	if len(movieIds) == 0 {
+		return in, nil
+	}
+
+	rows, err := q.pool.Query(ctx, inCollectionQuery, c.UserId, string(c.Collection), movieIds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check movies in collection: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var movieId int
+		if err := rows.Scan(&movieId); err != nil {
+			return nil, fmt.Errorf("failed to check movies in collection: %w", err)
+		}
+		in[movieId] = true
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("failed to check movies in collection: %w", rows.Err())
+	}
+
+	return in, nil
+}
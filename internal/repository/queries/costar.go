@@ -0,0 +1,153 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"vk-backend/internal/domain"
+)
+
+const listCostarsQuery = `
+SELECT c.id, c.name, c.gender, c.birth_date, c.shared
+FROM (SELECT a.id, a.name, a.gender, a.birth_date, count(DISTINCT other.movie_id)::int AS shared
+      FROM movie_actors source
+      JOIN movies m ON m.id = source.movie_id AND m.deleted_at IS NULL
+      JOIN movie_actors other ON other.movie_id = source.movie_id AND other.actor_id <> source.actor_id
+      JOIN actors a ON a.id = other.actor_id AND a.deleted_at IS NULL
+      WHERE source.actor_id = %s
+      GROUP BY a.id) c
+`
+
+// costarOrder lists the most frequent costars first
+var costarOrder = keyset[*domain.Costar]{name: "shared", keys: []sortKey[*domain.Costar]{
+	{
+		expr:  "c.shared",
+		typ:   "int",
+		desc:  true,
+		value: func(c *domain.Costar) string { return strconv.Itoa(c.SharedMovies) },
+	},
+	{
+		expr:  "c.id",
+		typ:   "int",
+		value: func(c *domain.Costar) string { return strconv.Itoa(c.Id) },
+	},
+}}
+
+// ListCostars returns a page of actors who played with the given one and a cursor of the next page,
+// which is empty for the last one
+func (q *Queries) ListCostars(ctx context.Context, actorId int, page domain.Page) ([]*domain.Costar, string, error) {
+	b := &queryBuilder{}
+	query := fmt.Sprintf(listCostarsQuery, b.arg(actorId))
+	if err := costarOrder.after(b, page.Cursor); err != nil {
+		return nil, "", err
+	}
+	limit := b.arg(page.Limit + 1)
+	query = fmt.Sprintf("%s %s ORDER BY %s LIMIT %s", query, b.whereClause(), costarOrder.orderBy(), limit)
+
+	rows, err := q.pool.Query(ctx, query, b.args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list costars: %w", err)
+	}
+	defer rows.Close()
+
+	var costars []*domain.Costar
+	for rows.Next() {
+		costar := &domain.Costar{Actor: &domain.Actor{}}
+		if err := rows.Scan(&costar.Id, &costar.Name, &costar.Gender, &costar.BirthDate, &costar.SharedMovies); err != nil {
+			return nil, "", fmt.Errorf("failed to list costars: %w", err)
+		}
+		costars = append(costars, costar)
+	}
+	if rows.Err() != nil {
+		return nil, "", fmt.Errorf("failed to list costars: %w", rows.Err())
+	}
+
+	costars, next := paginate(costars, page.Limit, costarOrder)
+
+	return costars, next, nil
+}
+
+// expandCostarsQuery links every actor of the frontier to the costars, a single movie per costar is enough to find a path
+const expandCostarsQuery = `
+SELECT DISTINCT ON (other.actor_id) source.actor_id, other.actor_id, source.movie_id
+FROM movie_actors source
+JOIN movies m ON m.id = source.movie_id AND m.deleted_at IS NULL
+JOIN movie_actors other ON other.movie_id = source.movie_id AND other.actor_id <> source.actor_id
+JOIN actors a ON a.id = other.actor_id AND a.deleted_at IS NULL
+WHERE source.actor_id = ANY($1) AND NOT other.actor_id = ANY($1)
+ORDER BY other.actor_id, source.movie_id
+`
+
+// ExpandCostars returns links from the actors to all their costars with a single query
+func (q *Queries) ExpandCostars(ctx context.Context, actorIds []int) ([]domain.CostarLink, error) {
+	rows, err := q.pool.Query(ctx, expandCostarsQuery, actorIds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand costars: %w", err)
+	}
+	defer rows.Close()
+
+	var links []domain.CostarLink
+	for rows.Next() {
+		var link domain.CostarLink
+		if err := rows.Scan(&link.From, &link.To, &link.MovieId); err != nil {
+			return nil, fmt.Errorf("failed to expand costars: %w", err)
+		}
+		links = append(links, link)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("failed to expand costars: %w", rows.Err())
+	}
+
+	return links, nil
+}
+
+const selectActorsByIdsQuery = `SELECT id, name, gender, birth_date FROM actors WHERE id = ANY($1) AND deleted_at IS NULL`
+
+func (q *Queries) GetActorsByIds(ctx context.Context, ids []int) (map[int]*domain.Actor, error) {
+	rows, err := q.pool.Query(ctx, selectActorsByIdsQuery, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select actors: %w", err)
+	}
+	defer rows.Close()
+
+	actors := make(map[int]*domain.Actor, len(ids))
+	for rows.Next() {
+		actor := &domain.Actor{}
+		if err := rows.Scan(&actor.Id, &actor.Name, &actor.Gender, &actor.BirthDate); err != nil {
+			return nil, fmt.Errorf("failed to select actors: %w", err)
+		}
+		actors[actor.Id] = actor
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("failed to select actors: %w", rows.Err())
+	}
+
+	return actors, nil
+}
+
+const selectMoviesByIdsQuery = `
+SELECT id, title, description, release_date, rating, score, review_count FROM movies WHERE id = ANY($1) AND deleted_at IS NULL
+`
+
+// GetMoviesByIds loads movies without casts and genres
+func (q *Queries) GetMoviesByIds(ctx context.Context, ids []int) (map[int]*domain.Movie, error) {
+	rows, err := q.pool.Query(ctx, selectMoviesByIdsQuery, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select movies: %w", err)
+	}
+	defer rows.Close()
+
+	movies := make(map[int]*domain.Movie, len(ids))
+	for rows.Next() {
+		movie := &domain.Movie{}
+		if err := rows.Scan(&movie.Id, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating, &movie.Score, &movie.ReviewCount); err != nil {
+			return nil, fmt.Errorf("failed to select movies: %w", err)
+		}
+		movies[movie.Id] = movie
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("failed to select movies: %w", rows.Err())
+	}
+
+	return movies, nil
+}
@@ -0,0 +1,197 @@
+package queries
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"vk-backend/internal/domain"
+)
+
+const createMovieStagingQuery = `
+CREATE TEMP TABLE movie_staging (external_id text, title text, description text, release_date date, rating numeric)
+ON COMMIT DROP
+`
+
+// the rating isn't updated, admins may have changed it since the first import
+const upsertExternalMoviesQuery = `
+INSERT INTO movies (external_id, title, description, release_date, rating)
+SELECT DISTINCT ON (external_id) external_id, title, description, release_date, rating
+FROM movie_staging
+ORDER BY external_id
+ON CONFLICT (external_id) DO UPDATE
+SET title = EXCLUDED.title, description = EXCLUDED.description, release_date = EXCLUDED.release_date, version = movies.version + 1, updated_at = now()
+WHERE (movies.title, movies.description, movies.release_date)
+          IS DISTINCT FROM (EXCLUDED.title, EXCLUDED.description, EXCLUDED.release_date)
+`
+
+// UpsertExternalMovies inserts new movies and updates the changed ones, it returns the number of rows written.
+// The checkpoint is saved in the same transaction, so the batch is either imported and counted or not at all.
+func (q *Queries) UpsertExternalMovies(ctx context.Context, movies []*domain.ExternalMovie, cp domain.Checkpoint) (int, error) {
+	return q.importBatch(ctx, cp, func(tx pgx.Tx) (int, error) {
+		if _, err := tx.Exec(ctx, createMovieStagingQuery); err != nil {
+			return 0, fmt.Errorf("failed to create movie staging: %w", err)
+		}
+		_, err := tx.CopyFrom(ctx, pgx.Identifier{"movie_staging"}, []string{"external_id", "title", "description", "release_date", "rating"},
+			pgx.CopyFromSlice(len(movies), func(i int) ([]any, error) {
+				m := movies[i]
+				return []any{m.ExternalId, m.Title, m.Description, m.ReleaseDate, m.Rating}, nil
+			}))
+		if err != nil {
+			return 0, fmt.Errorf("failed to copy movies: %w", err)
+		}
+
+		tag, err := tx.Exec(ctx, upsertExternalMoviesQuery)
+		if err != nil {
+			return 0, fmt.Errorf("failed to upsert movies: %w", err)
+		}
+		return int(tag.RowsAffected()), nil
+	})
+}
+
+const createActorStagingQuery = `
+CREATE TEMP TABLE actor_staging (external_id text, name text, gender int, birth_date date)
+ON COMMIT DROP
+`
+
+const upsertExternalActorsQuery = `
+INSERT INTO actors (external_id, name, gender, birth_date)
+SELECT DISTINCT ON (external_id) external_id, name, gender, birth_date
+FROM actor_staging
+ORDER BY external_id
+ON CONFLICT (external_id) DO UPDATE
+SET name = EXCLUDED.name, gender = EXCLUDED.gender, birth_date = EXCLUDED.birth_date, version = actors.version + 1, updated_at = now()
+WHERE (actors.name, actors.gender, actors.birth_date)
+          IS DISTINCT FROM (EXCLUDED.name, EXCLUDED.gender, EXCLUDED.birth_date)
+`
+
+// UpsertExternalActors inserts new actors and updates the changed ones, it returns the number of rows written
+func (q *Queries) UpsertExternalActors(ctx context.Context, actors []*domain.ExternalActor, cp domain.Checkpoint) (int, error) {
+	return q.importBatch(ctx, cp, func(tx pgx.Tx) (int, error) {
+		if _, err := tx.Exec(ctx, createActorStagingQuery); err != nil {
+			return 0, fmt.Errorf("failed to create actor staging: %w", err)
+		}
+		_, err := tx.CopyFrom(ctx, pgx.Identifier{"actor_staging"}, []string{"external_id", "name", "gender", "birth_date"},
+			pgx.CopyFromSlice(len(actors), func(i int) ([]any, error) {
+				a := actors[i]
+				return []any{a.ExternalId, a.Name, a.Gender, a.BirthDate}, nil
+			}))
+		if err != nil {
+			return 0, fmt.Errorf("failed to copy actors: %w", err)
+		}
+
+		tag, err := tx.Exec(ctx, upsertExternalActorsQuery)
+		if err != nil {
+			return 0, fmt.Errorf("failed to upsert actors: %w", err)
+		}
+		return int(tag.RowsAffected()), nil
+	})
+}
+
+const createCreditStagingQuery = `
+CREATE TEMP TABLE credit_staging (movie_id text, actor_id text, character_name text, billing int)
+ON COMMIT DROP
+`
+
+// credits of movies or actors which are not imported are skipped
+const upsertExternalCreditsQuery = `
+WITH credits AS (
+    SELECT DISTINCT ON (m.id, a.id) m.id AS movie_id, a.id AS actor_id, s.character_name, s.billing
+    FROM credit_staging s
+    JOIN movies m ON m.external_id = s.movie_id
+    JOIN actors a ON a.external_id = s.actor_id
+    ORDER BY m.id, a.id, s.billing
+), updated AS (
+    UPDATE movie_actors ma
+    SET character_name = c.character_name, billing = c.billing
+    FROM credits c
+    WHERE ma.movie_id = c.movie_id AND ma.actor_id = c.actor_id
+      AND (ma.character_name, ma.billing) IS DISTINCT FROM (c.character_name, c.billing)
+    RETURNING 1
+), inserted AS (
+    INSERT INTO movie_actors (movie_id, actor_id, character_name, billing)
+    SELECT c.movie_id, c.actor_id, c.character_name, c.billing
+    FROM credits c
+    WHERE NOT EXISTS (SELECT 1 FROM movie_actors ma WHERE ma.movie_id = c.movie_id AND ma.actor_id = c.actor_id)
+    RETURNING 1
+)
+SELECT (SELECT count(*) FROM updated) + (SELECT count(*) FROM inserted)
+`
+
+// UpsertExternalCredits casts imported actors in imported movies and updates the changed credits,
+// it returns the number of rows written
+func (q *Queries) UpsertExternalCredits(ctx context.Context, credits []*domain.ExternalCredit, cp domain.Checkpoint) (int, error) {
+	return q.importBatch(ctx, cp, func(tx pgx.Tx) (int, error) {
+		if _, err := tx.Exec(ctx, createCreditStagingQuery); err != nil {
+			return 0, fmt.Errorf("failed to create credit staging: %w", err)
+		}
+		_, err := tx.CopyFrom(ctx, pgx.Identifier{"credit_staging"}, []string{"movie_id", "actor_id", "character_name", "billing"},
+			pgx.CopyFromSlice(len(credits), func(i int) ([]any, error) {
+				c := credits[i]
+				return []any{c.MovieId, c.ActorId, c.Character, c.Billing}, nil
+			}))
+		if err != nil {
+			return 0, fmt.Errorf("failed to copy credits: %w", err)
+		}
+
+		var written int
+		if err := tx.QueryRow(ctx, upsertExternalCreditsQuery).Scan(&written); err != nil {
+			return 0, fmt.Errorf("failed to upsert credits: %w", err)
+		}
+		return written, nil
+	})
+}
+
+const saveCheckpointQuery = `
+INSERT INTO import_checkpoints (source, line) VALUES ($1, $2)
+ON CONFLICT (source) DO UPDATE SET line = EXCLUDED.line, updated_at = now()
+`
+
+func (q *Queries) importBatch(ctx context.Context, cp domain.Checkpoint, upsert func(tx pgx.Tx) (int, error)) (int, error) {
+	tx, err := q.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	written, err := upsert(tx)
+	if err != nil {
+		_ = tx.Rollback(ctx)
+		return 0, err
+	}
+	if _, err := tx.Exec(ctx, saveCheckpointQuery, cp.Source, cp.Line); err != nil {
+		_ = tx.Rollback(ctx)
+		return 0, fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return written, nil
+}
+
+const selectCheckpointQuery = `SELECT line FROM import_checkpoints WHERE source = $1`
+
+// GetCheckpoint returns how many lines of the source are imported, zero when the import hasn't started
+func (q *Queries) GetCheckpoint(ctx context.Context, source string) (int64, error) {
+	var line int64
+	err := q.pool.QueryRow(ctx, selectCheckpointQuery, source).Scan(&line)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get checkpoint: %w", err)
+	}
+
+	return line, nil
+}
+
+const deleteCheckpointsQuery = `DELETE FROM import_checkpoints WHERE source = ANY($1)`
+
+func (q *Queries) ResetCheckpoints(ctx context.Context, sources []string) error {
+	if _, err := q.pool.Exec(ctx, deleteCheckpointsQuery, sources); err != nil {
+		return fmt.Errorf("failed to reset checkpoints: %w", err)
+	}
+
+	return nil
+}
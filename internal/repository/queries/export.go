@@ -0,0 +1,154 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"vk-backend/internal/domain"
+)
+
+const exportBatchSize = 500
+
+const declareExportCursorQuery = `
+DECLARE export_movies NO SCROLL CURSOR FOR
+SELECT id, title, description, release_date, rating, score, review_count FROM movies WHERE deleted_at IS NULL ORDER BY id
+`
+
+var fetchExportQuery = fmt.Sprintf(`FETCH FORWARD %d FROM export_movies`, exportBatchSize)
+
+const declareExportActorsCursorQuery = `
+DECLARE export_actors NO SCROLL CURSOR FOR
+SELECT id, name, gender, birth_date FROM actors WHERE deleted_at IS NULL ORDER BY id
+`
+
+var fetchExportActorsQuery = fmt.Sprintf(`FETCH FORWARD %d FROM export_actors`, exportBatchSize)
+
+// ExportMovies passes every movie with its cast and genres to fn in the order of ids. Movies are fetched
+// from a cursor in batches within a read-only snapshot, so only a batch is kept in memory and the export is consistent.
+// An error returned by fn stops the export and is returned as is.
+func (q *Queries) ExportMovies(ctx context.Context, fn func(*domain.Movie) error) error {
+	return q.inSnapshot(ctx, func(tx pgx.Tx) error {
+		return exportMovies(ctx, tx, fn)
+	})
+}
+
+// ExportActors passes every actor to fn in the order of ids, including actors that aren't in any cast.
+// Actors are fetched the same way as movies in ExportMovies.
+func (q *Queries) ExportActors(ctx context.Context, fn func(*domain.Actor) error) error {
+	return q.inSnapshot(ctx, func(tx pgx.Tx) error {
+		return exportActors(ctx, tx, fn)
+	})
+}
+
+// inSnapshot runs the export in a read-only transaction, the error of the export is returned as is
+func (q *Queries) inSnapshot(ctx context.Context, export func(tx pgx.Tx) error) error {
+	tx, err := q.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := export(tx); err != nil {
+		_ = tx.Rollback(ctx)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func exportMovies(ctx context.Context, tx pgx.Tx, fn func(*domain.Movie) error) error {
+	if _, err := tx.Exec(ctx, declareExportCursorQuery); err != nil {
+		return fmt.Errorf("failed to declare export cursor: %w", err)
+	}
+
+	for {
+		movies, err := fetchMovies(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if err := loadCasts(ctx, tx, movies); err != nil {
+			return fmt.Errorf("failed to export movies: %w", err)
+		}
+		if err := loadGenres(ctx, tx, movies); err != nil {
+			return fmt.Errorf("failed to export movies: %w", err)
+		}
+
+		for _, movie := range movies {
+			if err := fn(movie); err != nil {
+				return err
+			}
+		}
+		if len(movies) < exportBatchSize {
+			return nil
+		}
+	}
+}
+
+func fetchMovies(ctx context.Context, tx pgx.Tx) ([]*domain.Movie, error) {
+	rows, err := tx.Query(ctx, fetchExportQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch movies: %w", err)
+	}
+	defer rows.Close()
+
+	movies := make([]*domain.Movie, 0, exportBatchSize)
+	for rows.Next() {
+		movie := &domain.Movie{}
+		if err := rows.Scan(&movie.Id, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating, &movie.Score, &movie.ReviewCount); err != nil {
+			return nil, fmt.Errorf("failed to fetch movies: %w", err)
+		}
+		movies = append(movies, movie)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("failed to fetch movies: %w", rows.Err())
+	}
+
+	return movies, nil
+}
+
+func exportActors(ctx context.Context, tx pgx.Tx, fn func(*domain.Actor) error) error {
+	if _, err := tx.Exec(ctx, declareExportActorsCursorQuery); err != nil {
+		return fmt.Errorf("failed to declare export cursor: %w", err)
+	}
+
+	for {
+		actors, err := fetchActors(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, actor := range actors {
+			if err := fn(actor); err != nil {
+				return err
+			}
+		}
+		if len(actors) < exportBatchSize {
+			return nil
+		}
+	}
+}
+
+func fetchActors(ctx context.Context, tx pgx.Tx) ([]*domain.Actor, error) {
+	rows, err := tx.Query(ctx, fetchExportActorsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch actors: %w", err)
+	}
+	defer rows.Close()
+
+	actors := make([]*domain.Actor, 0, exportBatchSize)
+	for rows.Next() {
+		actor := &domain.Actor{}
+		if err := rows.Scan(&actor.Id, &actor.Name, &actor.Gender, &actor.BirthDate); err != nil {
+			return nil, fmt.Errorf("failed to fetch actors: %w", err)
+		}
+		actors = append(actors, actor)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("failed to fetch actors: %w", rows.Err())
+	}
+
+	return actors, nil
+}
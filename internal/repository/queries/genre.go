@@ -0,0 +1,139 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"vk-backend/internal/domain"
+)
+
+const insertGenreQuery = `INSERT INTO genres (name) VALUES ($1) RETURNING id`
+
+func (q *Queries) AddGenre(ctx context.Context, name string) (*domain.Genre, error) {
+	genre := &domain.Genre{Name: name}
+	if err := q.pool.QueryRow(ctx, insertGenreQuery, name).Scan(&genre.Id); err != nil {
+		return nil, fmt.Errorf("failed to insert genre: %w", err)
+	}
+
+	return genre, nil
+}
+
+const selectGenreQuery = `SELECT name FROM genres WHERE id = $1`
+
+func (q *Queries) GetGenreById(ctx context.Context, id int) (*domain.Genre, error) {
+	genre := &domain.Genre{Id: id}
+	if err := q.pool.QueryRow(ctx, selectGenreQuery, id).Scan(&genre.Name); err != nil {
+		return nil, fmt.Errorf("failed to get genre: %w", err)
+	}
+
+	return genre, nil
+}
+
+const selectAllGenresQuery = `SELECT id, name FROM genres ORDER BY name`
+
+func (q *Queries) ListGenres(ctx context.Context) ([]*domain.Genre, error) {
+	rows, err := q.pool.Query(ctx, selectAllGenresQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select all genres: %w", err)
+	}
+	defer rows.Close()
+
+	var genres []*domain.Genre
+	for rows.Next() {
+		genre := &domain.Genre{}
+		if err := rows.Scan(&genre.Id, &genre.Name); err != nil {
+			return nil, fmt.Errorf("failed to list all the genres: %w", err)
+		}
+		genres = append(genres, genre)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("failed to list all the genres: %w", rows.Err())
+	}
+
+	return genres, nil
+}
+
+const updateGenreQuery = `UPDATE genres SET name = $2 WHERE id = $1`
+
+func (q *Queries) UpdateGenre(ctx context.Context, new *domain.Genre) error {
+	if _, err := q.pool.Exec(ctx, updateGenreQuery, new.Id, new.Name); err != nil {
+		return fmt.Errorf("failed to update genre: %w", err)
+	}
+
+	return nil
+}
+
+const deleteGenreQuery = `DELETE FROM genres WHERE id = $1`
+
+func (q *Queries) DeleteGenre(ctx context.Context, id int) error {
+	if _, err := q.pool.Exec(ctx, deleteGenreQuery, id); err != nil {
+		return fmt.Errorf("failed to delete genre: %w", err)
+	}
+
+	return nil
+}
+
+const existsGenreQuery = `SELECT EXISTS(SELECT 1 FROM genres WHERE id = $1)`
+
+func (q *Queries) GenreExists(ctx context.Context, id int) (bool, error) {
+	var exists bool
+	if err := q.pool.QueryRow(ctx, existsGenreQuery, id).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check if genre exists: %w", err)
+	}
+
+	return exists, nil
+}
+
+const existsGenreNameQuery = `SELECT EXISTS(SELECT 1 FROM genres WHERE name = $1)`
+
+func (q *Queries) GenreNameExists(ctx context.Context, name string) (bool, error) {
+	var exists bool
+	if err := q.pool.QueryRow(ctx, existsGenreNameQuery, name).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check if genre name exists: %w", err)
+	}
+
+	return exists, nil
+}
+
+const insertMovieGenreQuery = `INSERT INTO movie_genres (movie_id, genre_id) VALUES ($1, $2)`
+
+const getMovieGenresQuery = `
+SELECT mg.movie_id, g.id, g.name
+FROM movie_genres mg
+JOIN genres g ON g.id = mg.genre_id
+WHERE mg.movie_id = ANY($1)
+ORDER BY mg.movie_id, g.name
+`
+
+// loadGenres fills genres of all the given movies with a single query
+func loadGenres(ctx context.Context, db querier, movies []*domain.Movie) error {
+	if len(movies) == 0 {
+		return nil
+	}
+
+	byId := make(map[int]*domain.Movie, len(movies))
+	ids := make([]int, 0, len(movies))
+	for _, movie := range movies {
+		byId[movie.Id] = movie
+		ids = append(ids, movie.Id)
+	}
+
+	rows, err := db.Query(ctx, getMovieGenresQuery, ids)
+	if err != nil {
+		return fmt.Errorf("failed to select genres: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var movieId int
+		genre := &domain.Genre{}
+		if err := rows.Scan(&movieId, &genre.Id, &genre.Name); err != nil {
+			return fmt.Errorf("failed to scan genre: %w", err)
+		}
+		byId[movieId].Genres = append(byId[movieId].Genres, genre)
+	}
+	if rows.Err() != nil {
+		return fmt.Errorf("failed to select genres: %w", rows.Err())
+	}
+
+	return nil
+}
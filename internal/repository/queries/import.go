@@ -0,0 +1,139 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"time"
+	"vk-backend/internal/domain"
+)
+
+const selectActorsByNameAndBirthDateQuery = `
+SELECT a.id, a.name, a.gender, a.birth_date
+FROM actors a
+JOIN unnest($1::text[], $2::date[]) k(name, birth_date) ON a.name = k.name AND a.birth_date = k.birth_date
+WHERE a.deleted_at IS NULL
+`
+
+// FindActorsByNameAndBirthDate returns actors matching the name and the birth date of any of the given ones,
+// actors sharing both of them are all returned
+func (q *Queries) FindActorsByNameAndBirthDate(ctx context.Context, actors []*domain.Actor) ([]*domain.Actor, error) {
+	names, birthDates := make([]string, 0, len(actors)), make([]time.Time, 0, len(actors))
+	for _, actor := range actors {
+		names = append(names, actor.Name)
+		birthDates = append(birthDates, actor.BirthDate)
+	}
+
+	rows, err := q.pool.Query(ctx, selectActorsByNameAndBirthDateQuery, names, birthDates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find actors: %w", err)
+	}
+	defer rows.Close()
+
+	var found []*domain.Actor
+	for rows.Next() {
+		actor := &domain.Actor{}
+		if err := rows.Scan(&actor.Id, &actor.Name, &actor.Gender, &actor.BirthDate); err != nil {
+			return nil, fmt.Errorf("failed to find actors: %w", err)
+		}
+		found = append(found, actor)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("failed to find actors: %w", rows.Err())
+	}
+
+	return found, nil
+}
+
+const reserveIdsQuery = `SELECT nextval(pg_get_serial_sequence($1, 'id')) FROM generate_series(1, $2)`
+
+// ImportCatalog writes actors and movies with their casts in a single transaction using COPY.
+// Ids are reserved from the sequences beforehand and set on the given actors and movies,
+// so casts may refer to actors imported together with the movie.
+func (q *Queries) ImportCatalog(ctx context.Context, actors []*domain.Actor, movies []*domain.Movie) error {
+	tx, err := q.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := importCatalog(ctx, tx, actors, movies); err != nil {
+		_ = tx.Rollback(ctx)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func importCatalog(ctx context.Context, tx pgx.Tx, actors []*domain.Actor, movies []*domain.Movie) error {
+	actorIds, err := reserveIds(ctx, tx, "actors", len(actors))
+	if err != nil {
+		return err
+	}
+	for i, actor := range actors {
+		actor.Id = actorIds[i]
+	}
+	movieIds, err := reserveIds(ctx, tx, "movies", len(movies))
+	if err != nil {
+		return err
+	}
+	for i, movie := range movies {
+		movie.Id = movieIds[i]
+	}
+
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"actors"}, []string{"id", "name", "gender", "birth_date"},
+		pgx.CopyFromSlice(len(actors), func(i int) ([]any, error) {
+			a := actors[i]
+			return []any{a.Id, a.Name, a.Gender, a.BirthDate}, nil
+		}))
+	if err != nil {
+		return fmt.Errorf("failed to copy actors: %w", err)
+	}
+
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"movies"}, []string{"id", "title", "description", "release_date", "rating"},
+		pgx.CopyFromSlice(len(movies), func(i int) ([]any, error) {
+			m := movies[i]
+			return []any{m.Id, m.Title, m.Description, m.ReleaseDate, m.Rating}, nil
+		}))
+	if err != nil {
+		return fmt.Errorf("failed to copy movies: %w", err)
+	}
+
+	var credits [][]any
+	for _, movie := range movies {
+		for _, actor := range movie.Actors {
+			credits = append(credits, []any{movie.Id, actor.Id, actor.Character, actor.Billing})
+		}
+	}
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"movie_actors"}, []string{"movie_id", "actor_id", "character_name", "billing"},
+		pgx.CopyFromRows(credits))
+	if err != nil {
+		return fmt.Errorf("failed to copy casts: %w", err)
+	}
+
+	if err := writeCreatedAudit(ctx, tx, domain.AuditActor, actorIds); err != nil {
+		return err
+	}
+
+	return writeCreatedAudit(ctx, tx, domain.AuditMovie, movieIds)
+}
+
+func reserveIds(ctx context.Context, tx pgx.Tx, table string, n int) ([]int, error) {
+	if n == 0 {
+		return nil, nil
+	}
+	rows, err := tx.Query(ctx, reserveIdsQuery, table, n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve %s ids: %w", table, err)
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve %s ids: %w", table, err)
+	}
+
+	return ids, nil
+}
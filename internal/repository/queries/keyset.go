@@ -0,0 +1,155 @@
+package queries
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"vk-backend/internal/domain"
+)
+
+// sortKey is a single column of an ORDER BY clause
+type sortKey[T any] struct {
+	expr  string         // expression rows are ordered by
+	typ   string         // SQL type the cursor value is cast to
+	desc  bool           // descending order
+	value func(T) string // text representation of the expression value for the given row
+}
+
+// keyset is a total order of rows, that allows to continue listing right after any row without OFFSET.
+// The last key must be unique.
+type keyset[T any] struct {
+	name string // distinguishes cursors issued for different orders
+	keys []sortKey[T]
+}
+
+type cursorData struct {
+	Order  string   `json:"o"`
+	Values []string `json:"v"`
+}
+
+func (k keyset[T]) orderBy() string {
+	parts := make([]string, 0, len(k.keys))
+	for _, key := range k.keys {
+		if key.desc {
+			parts = append(parts, key.expr+" DESC")
+		} else {
+			parts = append(parts, key.expr)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// cursor encodes position of the row as an opaque page token
+func (k keyset[T]) cursor(row T) string {
+	c := cursorData{Order: k.name, Values: make([]string, 0, len(k.keys))}
+	for _, key := range k.keys {
+		c.Values = append(c.Values, key.value(row))
+	}
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// after adds condition that selects rows following the one encoded in the cursor
+func (k keyset[T]) after(b *queryBuilder, cursor string) error {
+	if cursor == "" {
+		return nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return domain.ErrInvalidCursor
+	}
+	c := cursorData{}
+	if err := json.Unmarshal(data, &c); err != nil || c.Order != k.name || len(c.Values) != len(k.keys) {
+		return domain.ErrInvalidCursor
+	}
+
+	values := make([]string, 0, len(k.keys))
+	for i, key := range k.keys {
+		if !validCursorValue(key.typ, c.Values[i]) {
+			return domain.ErrInvalidCursor
+		}
+		values = append(values, b.arg(c.Values[i])+"::"+key.typ)
+	}
+
+	if k.sameDirection() {
+		// row comparison can be served by a single index scan
+		exprs := make([]string, 0, len(k.keys))
+		for _, key := range k.keys {
+			exprs = append(exprs, key.expr)
+		}
+		op := ">"
+		if k.keys[0].desc {
+			op = "<"
+		}
+		b.where("(" + strings.Join(exprs, ", ") + ") " + op + " (" + strings.Join(values, ", ") + ")")
+		return nil
+	}
+
+	// (k1 > v1) OR (k1 = v1 AND k2 < v2) OR ...
+	alternatives := make([]string, 0, len(k.keys))
+	for i, key := range k.keys {
+		conds := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			conds = append(conds, k.keys[j].expr+" = "+values[j])
+		}
+		op := " > "
+		if key.desc {
+			op = " < "
+		}
+		conds = append(conds, key.expr+op+values[i])
+		alternatives = append(alternatives, "("+strings.Join(conds, " AND ")+")")
+	}
+	b.where("(" + strings.Join(alternatives, " OR ") + ")")
+
+	return nil
+}
+
+// validCursorValue tells if the value can be cast to the SQL type, values of tampered cursors would fail the query
+func validCursorValue(typ string, value string) bool {
+	switch typ {
+	case "int":
+		_, err := strconv.ParseInt(value, 10, 32)
+		return err == nil
+	case "bigint":
+		_, err := strconv.ParseInt(value, 10, 64)
+		return err == nil
+	case "numeric", "float8":
+		// hexadecimal and special values are parsed by Go only
+		f, err := strconv.ParseFloat(value, 64)
+		return err == nil && !math.IsNaN(f) && !math.IsInf(f, 0) && !strings.ContainsAny(value, "xX_")
+	case "date":
+		t, err := time.Parse(time.DateOnly, value)
+		return err == nil && t.Year() > 0
+	case "timestamptz":
+		// postgres takes offsets up to 15 hours and has no year zero
+		t, err := time.Parse(time.RFC3339Nano, value)
+		_, offset := t.Zone()
+		return err == nil && t.Year() > 0 && offset > -16*3600 && offset < 16*3600
+	case "text":
+		return !strings.ContainsRune(value, 0)
+	}
+
+	return false
+}
+
+func (k keyset[T]) sameDirection() bool {
+	for _, key := range k.keys[1:] {
+		if key.desc != k.keys[0].desc {
+			return false
+		}
+	}
+	return true
+}
+
+// paginate cuts the extra row fetched to find out if there is a next page and returns cursor pointing to it
+func paginate[T any](rows []T, limit int, order keyset[T]) ([]T, string) {
+	if len(rows) <= limit {
+		return rows, ""
+	}
+	rows = rows[:limit]
+	return rows, order.cursor(rows[len(rows)-1])
+}
@@ -0,0 +1,60 @@
+package queries
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"vk-backend/internal/domain"
+)
+
+func TestKeysetRejectsTamperedCursor(t *testing.T) {
+	order, err := movieOrder(domain.MovieSort{{Field: domain.MovieSortByRating, Desc: true}, {Field: domain.MovieSortByReleaseDate}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	cursor := func(values ...string) string {
+		data, _ := json.Marshal(cursorData{Order: order.name, Values: values})
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+
+	movie := &domain.Movie{Id: 42, Score: 7.5, ReleaseDate: time.Date(1999, 3, 31, 0, 0, 0, 0, time.UTC)}
+	assert.NoError(t, order.after(&queryBuilder{}, order.cursor(movie)))
+	assert.NoError(t, order.after(&queryBuilder{}, cursor("7", "1999-03-31", "1")))
+
+	for _, values := range [][]string{
+		{"seven", "1999-03-31", "42"},
+		{"NaN", "1999-03-31", "42"},
+		{"0x1p3", "1999-03-31", "42"},
+		{"7.5", "1999-02-31", "42"},
+		{"7.5", "0000-01-01", "42"},
+		{"7.5", "1999-03-31", "4.2"},
+		{"7.5", "1999-03-31", "99999999999"},
+	} {
+		assert.ErrorIs(t, order.after(&queryBuilder{}, cursor(values...)), domain.ErrInvalidCursor, values)
+	}
+}
+
+func TestValidCursorValue(t *testing.T) {
+	tests := []struct {
+		typ   string
+		value string
+		valid bool
+	}{
+		{typ: "bigint", value: "9007199254740993", valid: true},
+		{typ: "bigint", value: "1e3"},
+		{typ: "float8", value: "1.5e-7", valid: true},
+		{typ: "float8", value: "Inf"},
+		{typ: "timestamptz", value: "2024-05-01T12:00:00.123456Z", valid: true},
+		{typ: "timestamptz", value: "2024-05-01T12:00:00+20:00"},
+		{typ: "timestamptz", value: "2024-05-01"},
+		{typ: "text", value: "Keanu Reeves", valid: true},
+		{typ: "text", value: "Keanu\x00Reeves"},
+		{typ: "interval", value: "1 day"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.valid, validCursorValue(test.typ, test.value), test.typ+" "+test.value)
+	}
+}
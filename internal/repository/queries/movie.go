@@ -2,12 +2,16 @@ package queries
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/jackc/pgx/v5"
+	"strconv"
+	"strings"
 	"time"
 	"vk-backend/internal/domain"
 )
 
-const addMovieQuery = `INSERT INTO movies (title, description,release_date, rating) VALUES ($1, $2, $3, $4) RETURNING id`
+const addMovieQuery = `INSERT INTO movies (title, description,release_date, rating) VALUES ($1, $2, $3, $4) RETURNING id, version, created_at, updated_at`
 
 func (q *Queries) AddMovie(
 	ctx context.Context,
@@ -15,7 +19,8 @@ func (q *Queries) AddMovie(
 	description string,
 	releaseDate time.Time,
 	rating float64,
-	actors []*domain.Actor,
+	actors []*domain.CastMember,
+	genres []*domain.Genre,
 ) (*domain.Movie, error) {
 
 	tx, err := q.pool.Begin(ctx)
@@ -30,20 +35,32 @@ func (q *Queries) AddMovie(
 		Description: description,
 		ReleaseDate: releaseDate,
 		Rating:      rating,
+		Score:       rating,
 		Actors:      actors,
+		Genres:      genres,
 	}
-	if err := row.Scan(&movie.Id); err != nil {
+	if err := row.Scan(&movie.Id, &movie.Version, &movie.CreatedAt, &movie.UpdatedAt); err != nil {
 		_ = tx.Rollback(ctx)
 		return nil, fmt.Errorf("failed to add movie: %w", err)
 	}
 
-	for _, actor := range actors {
-		if _, err := tx.Exec(ctx, insertActorToMovieQuery, actor.Id, movie.Id); err != nil {
+	if err := insertCast(ctx, tx, movie.Id, actors); err != nil {
+		_ = tx.Rollback(ctx)
+		return nil, err
+	}
+
+	for _, genre := range genres {
+		if _, err := tx.Exec(ctx, insertMovieGenreQuery, movie.Id, genre.Id); err != nil {
 			_ = tx.Rollback(ctx)
-			return nil, fmt.Errorf("failed to insert actor to movie: %w", err)
+			return nil, fmt.Errorf("failed to insert genre to movie: %w", err)
 		}
 	}
 
+	if err := writeAudit(ctx, tx, domain.AuditCreate, domain.AuditMovie, movie.Id, nil); err != nil {
+		_ = tx.Rollback(ctx)
+		return nil, err
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
@@ -51,109 +68,478 @@ func (q *Queries) AddMovie(
 	return movie, nil
 }
 
-const getMovieByIdQuery = `SELECT id, title, description, release_date, rating FROM movies WHERE id = $1`
-const getMovieActorsQuery = `SELECT actor_id FROM movie_actors WHERE movie_id = $1`
+const getMovieByIdQuery = `
+SELECT id, title, description, release_date, rating, score, review_count, version, created_at, updated_at FROM movies WHERE id = $1 AND deleted_at IS NULL
+`
 
 func (q *Queries) GetMovieById(ctx context.Context, id int) (*domain.Movie, error) {
 	row := q.pool.QueryRow(ctx, getMovieByIdQuery, id)
 
 	movie := &domain.Movie{}
-	if err := row.Scan(&movie.Id, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating); err != nil {
+	if err := row.Scan(&movie.Id, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating, &movie.Score, &movie.ReviewCount, &movie.Version, &movie.CreatedAt, &movie.UpdatedAt); err != nil {
 		return nil, fmt.Errorf("failed to get movie by id: %w", err)
 	}
-	rows, err := q.pool.Query(ctx, getMovieActorsQuery, id)
-	if err != nil {
+	if err := loadCasts(ctx, q.pool, []*domain.Movie{movie}); err != nil {
 		return nil, fmt.Errorf("failed to get movie actors: %w", err)
 	}
+	if err := loadGenres(ctx, q.pool, []*domain.Movie{movie}); err != nil {
+		return nil, fmt.Errorf("failed to get movie genres: %w", err)
+	}
+
+	return movie, nil
+}
+
+const getCastsQuery = `
+SELECT ma.movie_id, a.id, a.name, a.gender, a.birth_date, ma.character_name, ma.billing
+FROM movie_actors ma
+JOIN actors a ON a.id = ma.actor_id
+WHERE ma.movie_id = ANY($1) AND a.deleted_at IS NULL
+ORDER BY ma.movie_id, ma.billing, ma.id
+`
+
+// loadCasts fills actors of all the given movies with a single query
+func loadCasts(ctx context.Context, db querier, movies []*domain.Movie) error {
+	if len(movies) == 0 {
+		return nil
+	}
+
+	byId := make(map[int]*domain.Movie, len(movies))
+	ids := make([]int, 0, len(movies))
+	for _, movie := range movies {
+		byId[movie.Id] = movie
+		ids = append(ids, movie.Id)
+	}
+
+	rows, err := db.Query(ctx, getCastsQuery, ids)
+	if err != nil {
+		return fmt.Errorf("failed to select casts: %w", err)
+	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var actorId int
-		if err := rows.Scan(&actorId); err != nil {
-			return nil, fmt.Errorf("failed to get movie actors: %w", err)
+		var movieId int
+		actor := &domain.CastMember{Actor: &domain.Actor{}}
+		if err := rows.Scan(&movieId, &actor.Id, &actor.Name, &actor.Gender, &actor.BirthDate, &actor.Character, &actor.Billing); err != nil {
+			return fmt.Errorf("failed to scan cast: %w", err)
 		}
-		actor, err := q.GetActorById(ctx, actorId)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get movie actors: %w", err)
-		}
-		movie.Actors = append(movie.Actors, actor)
+		byId[movieId].Actors = append(byId[movieId].Actors, actor)
 	}
 	if rows.Err() != nil {
-		return nil, fmt.Errorf("failed to get movie actors: %w", rows.Err())
+		return fmt.Errorf("failed to select casts: %w", rows.Err())
 	}
 
-	return movie, nil
+	return nil
 }
 
-const listMoviesQuery = `SELECT id, title, description, release_date, rating FROM movies`
+const (
+	listMoviesQuery = `SELECT m.id, m.title, m.description, m.release_date, m.rating, m.score, m.review_count, m.created_at, m.updated_at, %s, %s, %s FROM movies m %s`
 
-func (q *Queries) ListMovies(ctx context.Context) ([]*domain.Movie, error) {
-	rows, err := q.pool.Query(ctx, listMoviesQuery)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list movies: %w", err)
+	// searchConfig stems cyrillic words as russian and latin ones as english
+	searchConfig = "russian"
+
+	movieRelevanceExpr = `ts_rank_cd(m.search_vector, query)::float8`
+	// the headline is HTML, so the text is escaped the same way as html.EscapeString before the matches are wrapped in tags
+	movieHeadlineText = `replace(replace(replace(replace(replace(m.title || '. ' || m.description,
+	'&', '&amp;'), '''', '&#39;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;')`
+	movieHeadlineExpr = `ts_headline('` + searchConfig + `', ` + movieHeadlineText + `, query,
+	'StartSel=<b>, StopSel=</b>, MaxFragments=2, FragmentDelimiter=" ... "')`
+)
+
+var (
+	movieIdKey = sortKey[*domain.Movie]{
+		expr:  "m.id",
+		typ:   "int",
+		value: func(m *domain.Movie) string { return strconv.Itoa(m.Id) },
 	}
-	defer rows.Close()
 
-	var movies []*domain.Movie
-	for rows.Next() {
-		movie := &domain.Movie{}
-		if err := rows.Scan(&movie.Id, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating); err != nil {
-			return nil, fmt.Errorf("failed to list movies: %w", err)
+	// movieSortKeys are ascending, titles are compared bytewise, the same way Go compares strings
+	movieSortKeys = map[domain.MovieSortField]sortKey[*domain.Movie]{
+		domain.MovieSortByRating: {
+			expr:  "m.score",
+			typ:   "numeric",
+			value: func(m *domain.Movie) string { return strconv.FormatFloat(m.Score, 'f', -1, 64) },
+		},
+		domain.MovieSortByReleaseDate: {
+			expr:  "m.release_date",
+			typ:   "date",
+			value: func(m *domain.Movie) string { return m.ReleaseDate.Format(time.DateOnly) },
+		},
+		domain.MovieSortByTitle: {
+			expr:  `m.title COLLATE "C"`,
+			typ:   "text",
+			value: func(m *domain.Movie) string { return m.Title },
+		},
+		// only applicable with a full-text query
+		domain.MovieSortByRelevance: {
+			expr:  movieRelevanceExpr,
+			typ:   "float8",
+			value: func(m *domain.Movie) string { return strconv.FormatFloat(m.Relevance, 'g', -1, 64) },
+		},
+		// only applicable to a collection
+		domain.MovieSortByAdded: {
+			expr:  "uc.added_at",
+			typ:   "timestamptz",
+			value: func(m *domain.Movie) string { return m.AddedAt.Format(time.RFC3339Nano) },
+		},
+	}
+)
+
+// movieOrder makes a total order out of the sort keys by adding id as the last one
+func movieOrder(sort domain.MovieSort) (keyset[*domain.Movie], error) {
+	order := keyset[*domain.Movie]{keys: make([]sortKey[*domain.Movie], 0, len(sort)+1)}
+	names := make([]string, 0, len(sort))
+	for _, k := range sort {
+		key, ok := movieSortKeys[k.Field]
+		if !ok {
+			return order, domain.ErrInvalidSort
 		}
-		movies = append(movies, movie)
+		key.desc = k.Desc
+		order.keys = append(order.keys, key)
+		names = append(names, fmt.Sprintf("%d:%t", k.Field, k.Desc))
 	}
-	if rows.Err() != nil {
-		return nil, fmt.Errorf("failed to list movies: %w", rows.Err())
+	order.keys = append(order.keys, movieIdKey)
+	order.name = strings.Join(names, ",")
+
+	return order, nil
+}
+
+func buildListMoviesQuery(filter domain.MovieFilter, order keyset[*domain.Movie], page domain.Page) (string, []any, error) {
+	b := &queryBuilder{}
+	b.where("m.deleted_at IS NULL")
+	relevance, headline, addedAt, from := "0::float8", "''", "NULL::timestamptz", ""
+	if filter.Collection != nil {
+		from = fmt.Sprintf("JOIN user_movies uc ON uc.movie_id = m.id AND uc.user_id = %s AND uc.collection = %s",
+			b.arg(filter.Collection.UserId), b.arg(string(filter.Collection.Collection)))
+		addedAt = "uc.added_at"
+	}
+	if filter.Query != nil {
+		from += fmt.Sprintf(", websearch_to_tsquery('%s', %s) query", searchConfig, b.arg(*filter.Query))
+		relevance, headline = movieRelevanceExpr, movieHeadlineExpr
+		b.where("m.search_vector @@ query")
+	}
+	if filter.Title != nil {
+		// substring or a word similar to the term, so misspelled names are still found
+		pattern, term := b.arg(containsPattern(*filter.Title)), b.arg(*filter.Title)
+		b.where(fmt.Sprintf(`(m.title ILIKE %[1]s OR %[2]s <%% m.title OR EXISTS (
+	SELECT 1 FROM movie_actors ma JOIN actors a ON a.id = ma.actor_id AND a.deleted_at IS NULL
+	WHERE ma.movie_id = m.id AND (a.name ILIKE %[1]s OR %[2]s <%% a.name)))`, pattern, term))
+	}
+	if filter.ReleaseDateFrom != nil {
+		b.where("m.release_date >= " + b.arg(*filter.ReleaseDateFrom))
+	}
+	if filter.ReleaseDateTo != nil {
+		b.where("m.release_date <= " + b.arg(*filter.ReleaseDateTo))
+	}
+	if filter.RatingMin != nil {
+		b.where("m.score >= " + b.arg(*filter.RatingMin))
+	}
+	if filter.RatingMax != nil {
+		b.where("m.score <= " + b.arg(*filter.RatingMax))
+	}
+	if filter.UpdatedSince != nil {
+		b.where("m.updated_at >= " + b.arg(*filter.UpdatedSince))
+	}
+	if filter.Actor != nil {
+		b.where("EXISTS (SELECT 1 FROM movie_actors ma WHERE ma.movie_id = m.id AND ma.actor_id = " + b.arg(*filter.Actor) + ")")
+	}
+	if len(filter.Genres) > 0 {
+		genres := b.arg(filter.Genres) + "::int[]"
+		if filter.AllGenres {
+			b.where(fmt.Sprintf(`(SELECT count(*) FROM movie_genres mg WHERE mg.movie_id = m.id AND mg.genre_id = ANY(%s)) = cardinality(%[1]s)`, genres))
+		} else {
+			b.where(fmt.Sprintf(`EXISTS (SELECT 1 FROM movie_genres mg WHERE mg.movie_id = m.id AND mg.genre_id = ANY(%s))`, genres))
+		}
+	}
+	if err := order.after(b, page.Cursor); err != nil {
+		return "", nil, err
 	}
 
-	for _, movie := range movies {
-		rows, err := q.pool.Query(ctx, getMovieActorsQuery, movie.Id)
+	// one extra row tells if there is a next page
+	limit := b.arg(page.Limit + 1)
+
+	query := fmt.Sprintf(listMoviesQuery, relevance, headline, addedAt, from)
+	return fmt.Sprintf("%s %s ORDER BY %s LIMIT %s", query, b.whereClause(), order.orderBy(), limit), b.args, nil
+}
+
+// ListMovies returns a page of movies matching the filter and a cursor of the next page, which is empty for the last one
+func (q *Queries) ListMovies(ctx context.Context, filter domain.MovieFilter, sort domain.MovieSort, page domain.Page) ([]*domain.Movie, string, error) {
+	order, err := movieOrder(sort)
+	if err != nil {
+		return nil, "", err
+	}
+	query, args, err := buildListMoviesQuery(filter, order, page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var movies []*domain.Movie
+	list := func(db querier) error {
+		rows, err := db.Query(ctx, query, args...)
 		if err != nil {
-			return nil, fmt.Errorf("failed to list movies: %w", err)
+			return fmt.Errorf("failed to list movies: %w", err)
 		}
 		defer rows.Close()
 
 		for rows.Next() {
-			var actorId int
-			if err := rows.Scan(&actorId); err != nil {
-				return nil, fmt.Errorf("failed to list movies: %w", err)
+			movie := &domain.Movie{}
+			if err := rows.Scan(&movie.Id, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating, &movie.Score, &movie.ReviewCount, &movie.CreatedAt, &movie.UpdatedAt, &movie.Relevance, &movie.Headline, &movie.AddedAt); err != nil {
+				return fmt.Errorf("failed to list movies: %w", err)
 			}
-			actor, err := q.GetActorById(ctx, actorId)
-			if err != nil {
-				return nil, fmt.Errorf("failed to list movies: %w", err)
-			}
-			movie.Actors = append(movie.Actors, actor)
+			movies = append(movies, movie)
 		}
 		if rows.Err() != nil {
-			return nil, fmt.Errorf("failed to list movies: %w", rows.Err())
+			return fmt.Errorf("failed to list movies: %w", rows.Err())
+		}
+		return nil
+	}
+
+	if filter.Title != nil {
+		err = q.withWordSimilarityThreshold(ctx, fuzzyMatchThreshold, func(tx pgx.Tx) error { return list(tx) })
+	} else {
+		err = list(q.pool)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	movies, next := paginate(movies, page.Limit, order)
+
+	if err := loadCasts(ctx, q.pool, movies); err != nil {
+		return nil, "", fmt.Errorf("failed to list movies: %w", err)
+	}
+	if err := loadGenres(ctx, q.pool, movies); err != nil {
+		return nil, "", fmt.Errorf("failed to list movies: %w", err)
+	}
+
+	return movies, next, nil
+}
+
+// similarMoviesQuery ranks movies by shared actors, an actor weighs 1 when top-billed in both movies and less
+// the lower the billing. The sum is scaled from a half to the full value by the score of the movie.
+const similarMoviesQuery = `
+SELECT m.id, m.title, m.description, m.release_date, m.rating, m.score, m.review_count,
+       (s.shared * (0.5 + m.score / 20))::float8 AS similarity
+FROM (SELECT other.movie_id, sum(2.0 / (source.billing + other.billing)) AS shared
+      FROM movie_actors source
+      JOIN movie_actors other ON other.actor_id = source.actor_id AND other.movie_id <> source.movie_id
+      JOIN actors a ON a.id = source.actor_id AND a.deleted_at IS NULL
+      WHERE source.movie_id = $1
+      GROUP BY other.movie_id) s
+JOIN movies m ON m.id = s.movie_id AND m.deleted_at IS NULL
+ORDER BY similarity DESC, m.id
+LIMIT $2
+`
+
+// SimilarMovies returns movies sharing the most actors with the given one, the most similar go first
+func (q *Queries) SimilarMovies(ctx context.Context, movieId int, limit int) ([]*domain.Movie, error) {
+	rows, err := q.pool.Query(ctx, similarMoviesQuery, movieId, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select similar movies: %w", err)
+	}
+	defer rows.Close()
+
+	var movies []*domain.Movie
+	for rows.Next() {
+		movie := &domain.Movie{}
+		if err := rows.Scan(&movie.Id, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating, &movie.Score, &movie.ReviewCount, &movie.Similarity); err != nil {
+			return nil, fmt.Errorf("failed to scan similar movie: %w", err)
 		}
+		movies = append(movies, movie)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("failed to select similar movies: %w", rows.Err())
+	}
 
+	if err := loadCasts(ctx, q.pool, movies); err != nil {
+		return nil, fmt.Errorf("failed to select similar movies: %w", err)
+	}
+	if err := loadGenres(ctx, q.pool, movies); err != nil {
+		return nil, fmt.Errorf("failed to select similar movies: %w", err)
 	}
 
 	return movies, nil
 }
 
-const updateMovieQuery = `UPDATE movies SET title = $2, description = $3, release_date = $4, rating = $5 WHERE id = $1`
+const updateMovieQuery = `
+UPDATE movies SET title = $2, description = $3, release_date = $4, rating = $5, version = version + 1, updated_at = now()
+WHERE id = $1 AND deleted_at IS NULL AND version = $6
+RETURNING version
+`
+
+const bumpMovieVersionQuery = `
+UPDATE movies SET version = version + 1, updated_at = now()
+WHERE id = $1 AND deleted_at IS NULL AND version = $2
+RETURNING version
+`
+
+const deleteMovieGenresQuery = `DELETE FROM movie_genres WHERE movie_id = $1`
 
+// UpdateMovie overwrites the movie of the given version, replaces its cast and genres and sets the new version.
+// The new state is saved as a revision. It fails with domain.ErrVersionMismatch if the movie was changed since that version.
 func (q *Queries) UpdateMovie(ctx context.Context, new *domain.Movie) error {
-	if _, err := q.pool.Exec(ctx, updateMovieQuery, new.Id, new.Title, new.Description, new.ReleaseDate, new.Rating); err != nil {
+	_, err := q.audited(ctx, domain.AuditUpdate, domain.AuditMovie, new.Id, versioned(ctx, domain.AuditMovie, new.Id, new.Version, func(tx pgx.Tx) error {
+		return updateMovie(ctx, tx, new)
+	}))
+
+	return err
+}
+
+func updateMovie(ctx context.Context, tx pgx.Tx, new *domain.Movie) error {
+	err := tx.QueryRow(ctx, updateMovieQuery, new.Id, new.Title, new.Description, new.ReleaseDate, new.Rating, new.Version).Scan(&new.Version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrVersionMismatch
+	}
+	if err != nil {
 		return fmt.Errorf("failed to update movie: %w", err)
 	}
 
+	if err := replaceCast(ctx, tx, new.Id, new.Actors); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, deleteMovieGenresQuery, new.Id); err != nil {
+		return fmt.Errorf("failed to delete movie genres: %w", err)
+	}
+	for _, genre := range new.Genres {
+		if _, err := tx.Exec(ctx, insertMovieGenreQuery, new.Id, genre.Id); err != nil {
+			return fmt.Errorf("failed to insert genre to movie: %w", err)
+		}
+	}
+
 	return nil
 }
 
-const deleteMovieQuery = `DELETE FROM movies WHERE id = $1`
+// credits of actors in the trash are kept, so they are back in the cast when the actor is restored
+const deleteCastQuery = `
+DELETE FROM movie_actors ma USING actors a
+WHERE ma.movie_id = $1 AND a.id = ma.actor_id AND a.deleted_at IS NULL
+`
+
+// ReplaceCast swaps the whole cast of the movie of the given version at once and returns the new version,
+// the movie is saved as a revision
+func (q *Queries) ReplaceCast(ctx context.Context, movieId int, cast []*domain.CastMember, version int) (int, error) {
+	_, err := q.audited(ctx, domain.AuditUpdate, domain.AuditCast, movieId, versioned(ctx, domain.AuditMovie, movieId, version, func(tx pgx.Tx) error {
+		if err := replaceCast(ctx, tx, movieId, cast); err != nil {
+			return err
+		}
+		return bumpMovieVersion(ctx, tx, movieId, &version)
+	}))
 
-func (q *Queries) DeleteMovie(ctx context.Context, id int) error {
-	if _, err := q.pool.Exec(ctx, deleteMovieQuery, id); err != nil {
-		return fmt.Errorf("failed to delete movie: %w", err)
+	return version, err
+}
+
+// bumpMovieVersion marks the movie of the given version changed, when its cast is changed apart from the movie itself,
+// and sets the new version
+func bumpMovieVersion(ctx context.Context, tx pgx.Tx, movieId int, version *int) error {
+	err := tx.QueryRow(ctx, bumpMovieVersionQuery, movieId, *version).Scan(version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrVersionMismatch
+	}
+	if err != nil {
+		return fmt.Errorf("failed to bump movie version: %w", err)
+	}
+
+	return nil
+}
+
+func replaceCast(ctx context.Context, tx pgx.Tx, movieId int, cast []*domain.CastMember) error {
+	if _, err := tx.Exec(ctx, deleteCastQuery, movieId); err != nil {
+		return fmt.Errorf("failed to delete movie cast: %w", err)
+	}
+	if err := insertCast(ctx, tx, movieId, cast); err != nil {
+		return err
+	}
+
+	return billTrashedCredits(ctx, tx, movieId)
+}
+
+// billTrashedCreditsQuery bills credits of actors in the trash after the cast in their former order
+const billTrashedCreditsQuery = `
+UPDATE movie_actors ma
+SET billing = trashed.billing
+FROM (
+    SELECT t.id, (
+        SELECT COALESCE(MAX(c.billing), 0)
+        FROM movie_actors c JOIN actors ca ON ca.id = c.actor_id AND ca.deleted_at IS NULL
+        WHERE c.movie_id = $1
+    ) + row_number() OVER (ORDER BY t.billing, t.id) AS billing
+    FROM movie_actors t JOIN actors a ON a.id = t.actor_id AND a.deleted_at IS NOT NULL
+    WHERE t.movie_id = $1
+) trashed
+WHERE ma.id = trashed.id
+`
+
+// billTrashedCredits keeps credits of actors in the trash out of the billings of the cast, which is checked
+// without them, so restored actors come back after the cast instead of sharing billings with it
+func billTrashedCredits(ctx context.Context, tx pgx.Tx, movieId int) error {
+	if _, err := tx.Exec(ctx, billTrashedCreditsQuery, movieId); err != nil {
+		return fmt.Errorf("failed to bill credits of deleted actors: %w", err)
+	}
+	return nil
+}
+
+func insertCast(ctx context.Context, tx pgx.Tx, movieId int, cast []*domain.CastMember) error {
+	for _, actor := range cast {
+		if _, err := tx.Exec(ctx, insertActorToMovieQuery, actor.Id, movieId, actor.Character, actor.Billing); err != nil {
+			if isUniqueViolation(err) {
+				return domain.ErrActorAlreadyInMovie
+			}
+			return fmt.Errorf("failed to insert actor to movie: %w", err)
+		}
 	}
 
 	return nil
 }
 
-const existsMovieQuery = `SELECT EXISTS(SELECT 1 FROM movies WHERE id = $1)`
+// removeActorFromMovieQuery closes the gap the actor leaves in the billing
+const removeActorFromMovieQuery = `
+WITH removed AS (
+    DELETE FROM movie_actors WHERE movie_id = $1 AND actor_id = $2 RETURNING billing
+)
+UPDATE movie_actors
+SET billing = billing - 1
+WHERE movie_id = $1 AND billing > (SELECT MIN(billing) FROM removed)
+`
+
+// RemoveActorFromMovie takes the actor out of the cast of the movie of the given version and returns the new version
+func (q *Queries) RemoveActorFromMovie(ctx context.Context, actorId int, movieId int, version int) (int, error) {
+	_, err := q.audited(ctx, domain.AuditUpdate, domain.AuditCast, movieId, versioned(ctx, domain.AuditMovie, movieId, version, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, removeActorFromMovieQuery, movieId, actorId); err != nil {
+			return fmt.Errorf("failed to remove actor from movie: %w", err)
+		}
+		return bumpMovieVersion(ctx, tx, movieId, &version)
+	}))
+
+	return version, err
+}
+
+// movies are moved to the trash along with their casts, genres and reviews
+const deleteMovieQuery = `
+UPDATE movies SET deleted_at = now(), version = version + 1, updated_at = now()
+WHERE id = $1 AND deleted_at IS NULL AND version = $2
+`
+
+// DeleteMovie moves the movie of the given version to the trash,
+// it fails with domain.ErrVersionMismatch if the movie was changed since that version
+func (q *Queries) DeleteMovie(ctx context.Context, id int, version int) error {
+	_, err := q.audited(ctx, domain.AuditDelete, domain.AuditMovie, id, func(tx pgx.Tx) (bool, error) {
+		if err := lockVersion(ctx, tx, domain.AuditMovie, id, version); err != nil {
+			return false, err
+		}
+		tag, err := tx.Exec(ctx, deleteMovieQuery, id, version)
+		if err != nil {
+			return false, fmt.Errorf("failed to delete movie: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return false, domain.ErrVersionMismatch
+		}
+		return true, nil
+	})
+
+	return err
+}
+
+const existsMovieQuery = `SELECT EXISTS(SELECT 1 FROM movies WHERE id = $1 AND deleted_at IS NULL)`
 
 func (q *Queries) MovieExists(ctx context.Context, id int) (bool, error) {
 	var exists bool
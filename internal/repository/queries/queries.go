@@ -1,9 +1,16 @@
 package queries
 
 import (
+	"context"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// querier is implemented by both the pool and transactions
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
 type Queries struct {
 	pool *pgxpool.Pool
 }
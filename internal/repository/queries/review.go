@@ -0,0 +1,116 @@
+package queries
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"strconv"
+	"vk-backend/internal/domain"
+)
+
+const reviewColumns = `r.id, r.movie_id, r.user_id, u.username, r.rating, r.text, r.created_at, r.updated_at`
+
+// upsertReviewQuery keeps a single review per user and movie, a repeated one overwrites the previous
+const upsertReviewQuery = `
+WITH r AS (
+    INSERT INTO reviews (movie_id, user_id, rating, text)
+    VALUES ($1, $2, $3, $4)
+    ON CONFLICT (movie_id, user_id) DO UPDATE SET rating = EXCLUDED.rating, text = EXCLUDED.text, updated_at = now()
+    RETURNING *
+)
+SELECT ` + reviewColumns + `
+FROM r
+JOIN users u ON u.id = r.user_id
+`
+
+func (q *Queries) SaveReview(ctx context.Context, movieId int, userId int, rating int, text string) (*domain.Review, error) {
+	review := &domain.Review{}
+	if err := scanReview(q.pool.QueryRow(ctx, upsertReviewQuery, movieId, userId, rating, text), review); err != nil {
+		return nil, fmt.Errorf("failed to save review: %w", err)
+	}
+
+	return review, nil
+}
+
+const selectReviewQuery = `
+SELECT ` + reviewColumns + `
+FROM reviews r
+JOIN users u ON u.id = r.user_id
+WHERE r.movie_id = $1 AND r.user_id = $2
+`
+
+// GetReview returns the review the user left on the movie, domain.ErrReviewNotExists if there is none
+func (q *Queries) GetReview(ctx context.Context, movieId int, userId int) (*domain.Review, error) {
+	review := &domain.Review{}
+	err := scanReview(q.pool.QueryRow(ctx, selectReviewQuery, movieId, userId), review)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrReviewNotExists
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+
+	return review, nil
+}
+
+const listReviewsQuery = `
+SELECT ` + reviewColumns + `
+FROM reviews r
+JOIN users u ON u.id = r.user_id
+`
+
+// reviewOrder lists the newest reviews first
+var reviewOrder = keyset[*domain.Review]{name: "newest", keys: []sortKey[*domain.Review]{{
+	expr:  "r.id",
+	typ:   "int",
+	desc:  true,
+	value: func(r *domain.Review) string { return strconv.Itoa(r.Id) },
+}}}
+
+// ListReviews returns a page of reviews of the movie and a cursor of the next page, which is empty for the last one
+func (q *Queries) ListReviews(ctx context.Context, movieId int, page domain.Page) ([]*domain.Review, string, error) {
+	b := &queryBuilder{}
+	b.where("r.movie_id = " + b.arg(movieId))
+	if err := reviewOrder.after(b, page.Cursor); err != nil {
+		return nil, "", err
+	}
+	limit := b.arg(page.Limit + 1)
+	query := fmt.Sprintf("%s %s ORDER BY %s LIMIT %s", listReviewsQuery, b.whereClause(), reviewOrder.orderBy(), limit)
+
+	rows, err := q.pool.Query(ctx, query, b.args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list reviews: %w", err)
+	}
+	defer rows.Close()
+
+	var reviews []*domain.Review
+	for rows.Next() {
+		review := &domain.Review{}
+		if err := scanReview(rows, review); err != nil {
+			return nil, "", fmt.Errorf("failed to list reviews: %w", err)
+		}
+		reviews = append(reviews, review)
+	}
+	if rows.Err() != nil {
+		return nil, "", fmt.Errorf("failed to list reviews: %w", rows.Err())
+	}
+
+	reviews, next := paginate(reviews, page.Limit, reviewOrder)
+
+	return reviews, next, nil
+}
+
+const deleteReviewQuery = `DELETE FROM reviews WHERE movie_id = $1 AND user_id = $2`
+
+func (q *Queries) DeleteReview(ctx context.Context, movieId int, userId int) error {
+	if _, err := q.pool.Exec(ctx, deleteReviewQuery, movieId, userId); err != nil {
+		return fmt.Errorf("failed to delete review: %w", err)
+	}
+
+	return nil
+}
+
+func scanReview(row pgx.Row, review *domain.Review) error {
+	return row.Scan(&review.Id, &review.MovieId, &review.UserId, &review.Username, &review.Rating, &review.Text, &review.CreatedAt, &review.UpdatedAt)
+}
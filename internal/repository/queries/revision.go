@@ -0,0 +1,258 @@
+package queries
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"strconv"
+	"time"
+	"vk-backend/internal/domain"
+)
+
+// revisions keep the same snapshots as the audit log, except for the trash, that isn't versioned
+const (
+	insertFirstRevisionQuery = `
+INSERT INTO revisions (entity, entity_id, number, data)
+SELECT $1, %[1]s, 1, (%[2]s) - 'deleted_at' FROM %[3]s
+WHERE %[1]s = $2 AND NOT EXISTS(SELECT 1 FROM revisions WHERE entity = $1 AND entity_id = $2)
+`
+	insertRevisionQuery = `
+INSERT INTO revisions (entity, entity_id, number, data, user_id)
+SELECT $1, %[1]s, (SELECT COALESCE(MAX(number), 0) + 1 FROM revisions WHERE entity = $1 AND entity_id = $2), (%[2]s) - 'deleted_at', $3
+FROM %[3]s
+WHERE %[1]s = $2
+`
+	deleteRevisionsQuery = `DELETE FROM revisions WHERE entity = $1 AND entity_id = $2`
+)
+
+// startRevisions saves the current state of the entity as the first revision unless it has revisions already,
+// it must be called before the first change of the entity in the transaction
+func startRevisions(ctx context.Context, tx pgx.Tx, entity domain.AuditEntity, id int) error {
+	s := auditSnapshots[entity]
+	query := fmt.Sprintf(insertFirstRevisionQuery, s.id, s.expr, s.table)
+
+	if _, err := tx.Exec(ctx, query, string(entity), id); err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrVersionMismatch
+		}
+		return fmt.Errorf("failed to save first %s revision: %w", entity, err)
+	}
+
+	return nil
+}
+
+// writeRevision saves the state of the entity changed in the transaction as the next revision
+func writeRevision(ctx context.Context, tx pgx.Tx, entity domain.AuditEntity, id int) error {
+	s := auditSnapshots[entity]
+	query := fmt.Sprintf(insertRevisionQuery, s.id, s.expr, s.table)
+
+	if _, err := tx.Exec(ctx, query, string(entity), id, auditUserId(ctx)); err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrVersionMismatch
+		}
+		return fmt.Errorf("failed to save %s revision: %w", entity, err)
+	}
+
+	return nil
+}
+
+// versionedEntity is the table of the entity and the error returned when it's gone or in the trash
+type versionedEntity struct {
+	table     string
+	notExists error
+}
+
+var versionedEntities = map[domain.AuditEntity]versionedEntity{
+	domain.AuditActor: {table: "actors", notExists: domain.ErrActorNotExists},
+	domain.AuditMovie: {table: "movies", notExists: domain.ErrMovieNotExists},
+}
+
+// lockVersion locks the row of the entity until the end of the transaction, so concurrent changes
+// are made and numbered one after another. It fails with domain.ErrVersionMismatch if the entity
+// was changed since the version.
+func lockVersion(ctx context.Context, tx pgx.Tx, entity domain.AuditEntity, id int, version int) error {
+	e := versionedEntities[entity]
+	query := fmt.Sprintf("SELECT version FROM %s WHERE id = $1 AND deleted_at IS NULL FOR UPDATE", e.table)
+
+	var current int
+	err := tx.QueryRow(ctx, query, id).Scan(&current)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return e.notExists
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock %s: %w", entity, err)
+	}
+	if current != version {
+		return domain.ErrVersionMismatch
+	}
+
+	return nil
+}
+
+// isUniqueViolation tells if the statement failed on a unique constraint
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// versioned wraps the change of the entity of the given version, so the entity is saved as a revision before and after it.
+// The entity is locked first, a concurrent change that still collides on a revision number
+// fails with domain.ErrVersionMismatch.
+func versioned(ctx context.Context, entity domain.AuditEntity, id int, version int, change func(tx pgx.Tx) error) func(tx pgx.Tx) (bool, error) {
+	return func(tx pgx.Tx) (bool, error) {
+		if err := lockVersion(ctx, tx, entity, id, version); err != nil {
+			return false, err
+		}
+		if err := startRevisions(ctx, tx, entity, id); err != nil {
+			return false, err
+		}
+		if err := change(tx); err != nil {
+			return false, err
+		}
+		return true, writeRevision(ctx, tx, entity, id)
+	}
+}
+
+const listRevisionsQuery = `
+SELECT r.number, r.user_id, r.created_at
+FROM revisions r
+`
+
+// revisionOrder lists the latest revisions first
+var revisionOrder = keyset[*domain.Revision]{name: "revision", keys: []sortKey[*domain.Revision]{{
+	expr:  "r.number",
+	typ:   "int",
+	desc:  true,
+	value: func(r *domain.Revision) string { return strconv.Itoa(r.Number) },
+}}}
+
+// ListRevisions returns a page of revisions of the movie or the actor without their data
+// and a cursor of the next page, which is empty for the last one
+func (q *Queries) ListRevisions(ctx context.Context, entity domain.AuditEntity, id int, page domain.Page) ([]*domain.Revision, string, error) {
+	b := &queryBuilder{}
+	b.where("r.entity = " + b.arg(string(entity)))
+	b.where("r.entity_id = " + b.arg(id))
+	if err := revisionOrder.after(b, page.Cursor); err != nil {
+		return nil, "", err
+	}
+	query := fmt.Sprintf("%s %s ORDER BY %s LIMIT %s", listRevisionsQuery, b.whereClause(), revisionOrder.orderBy(), b.arg(page.Limit+1))
+
+	rows, err := q.pool.Query(ctx, query, b.args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []*domain.Revision
+	for rows.Next() {
+		revision := &domain.Revision{}
+		if err := rows.Scan(&revision.Number, &revision.UserId, &revision.At); err != nil {
+			return nil, "", fmt.Errorf("failed to list revisions: %w", err)
+		}
+		revisions = append(revisions, revision)
+	}
+	if rows.Err() != nil {
+		return nil, "", fmt.Errorf("failed to list revisions: %w", rows.Err())
+	}
+
+	revisions, next := paginate(revisions, page.Limit, revisionOrder)
+
+	return revisions, next, nil
+}
+
+const selectRevisionQuery = `
+SELECT number, user_id, created_at, data
+FROM revisions
+WHERE entity = $1 AND entity_id = $2 AND number = $3
+`
+
+// movieRevision is the movie snapshot
+type movieRevision struct {
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	ReleaseDate string  `json:"release_date"`
+	Rating      float64 `json:"rating"`
+	Genres      []int   `json:"genres"`
+	Cast        []struct {
+		ActorId   int    `json:"actor_id"`
+		Character string `json:"character"`
+		Billing   int    `json:"billing"`
+	} `json:"cast"`
+}
+
+// actorRevision is the actor snapshot
+type actorRevision struct {
+	Name      string `json:"name"`
+	Gender    int    `json:"gender"`
+	BirthDate string `json:"birth_date"`
+}
+
+// GetRevision returns the revision of the movie or the actor with its data decoded
+func (q *Queries) GetRevision(ctx context.Context, entity domain.AuditEntity, id int, number int) (*domain.Revision, error) {
+	revision := &domain.Revision{}
+	err := q.pool.QueryRow(ctx, selectRevisionQuery, string(entity), id, number).
+		Scan(&revision.Number, &revision.UserId, &revision.At, &revision.Data)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrRevisionNotExists
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revision: %w", err)
+	}
+
+	switch entity {
+	case domain.AuditMovie:
+		revision.Movie, err = decodeMovieRevision(id, revision.Data)
+	case domain.AuditActor:
+		revision.Actor, err = decodeActorRevision(id, revision.Data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s revision: %w", entity, err)
+	}
+
+	return revision, nil
+}
+
+func decodeMovieRevision(id int, data []byte) (*domain.Movie, error) {
+	r := movieRevision{}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+	releaseDate, err := time.Parse(time.DateOnly, r.ReleaseDate)
+	if err != nil {
+		return nil, err
+	}
+
+	movie := &domain.Movie{
+		Id:          id,
+		Title:       r.Title,
+		Description: r.Description,
+		ReleaseDate: releaseDate,
+		Rating:      r.Rating,
+		Actors:      make([]*domain.CastMember, 0, len(r.Cast)),
+		Genres:      make([]*domain.Genre, 0, len(r.Genres)),
+	}
+	for _, c := range r.Cast {
+		movie.Actors = append(movie.Actors, &domain.CastMember{Actor: &domain.Actor{Id: c.ActorId}, Character: c.Character, Billing: c.Billing})
+	}
+	for _, genreId := range r.Genres {
+		movie.Genres = append(movie.Genres, &domain.Genre{Id: genreId})
+	}
+
+	return movie, nil
+}
+
+func decodeActorRevision(id int, data []byte) (*domain.Actor, error) {
+	r := actorRevision{}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+	birthDate, err := time.Parse(time.DateOnly, r.BirthDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Actor{Id: id, Name: r.Name, Gender: r.Gender, BirthDate: birthDate}, nil
+}
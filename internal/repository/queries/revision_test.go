@@ -0,0 +1,142 @@
+package queries
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"vk-backend/internal/domain"
+)
+
+// requireDB skips tests that need the migrated database of the benchmarks
+func requireDB(t *testing.T) *Queries {
+	if benchPool == nil {
+		t.Skip("BENCH_DB_URL is not set")
+	}
+	return NewQueries(benchPool)
+}
+
+func TestCastChangesAreRevisions(t *testing.T) {
+	q := requireDB(t)
+	ctx := context.Background()
+	date := time.Date(1999, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	// the movie and the actor are tagged, so they are removed with the seeded rows
+	actor, err := q.AddActor(ctx, benchTag+" cast revision", 1, date)
+	if !assert.NoError(t, err) {
+		return
+	}
+	movie, err := q.AddMovie(ctx, "cast revision", benchTag, date, 5, nil, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	t.Cleanup(func() {
+		_, _ = benchPool.Exec(ctx, deleteRevisionsQuery, string(domain.AuditMovie), movie.Id)
+	})
+
+	revisionCount := func() int {
+		revisions, _, err := q.ListRevisions(ctx, domain.AuditMovie, movie.Id, domain.Page{Limit: 10})
+		assert.NoError(t, err)
+		return len(revisions)
+	}
+
+	// the first change saves the movie as it was created as well
+	version, err := q.AddActorToMovie(ctx, actor.Id, movie.Id, "hero", 0, movie.Version)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, revisionCount())
+
+	version, err = q.ReplaceCast(ctx, movie.Id, []*domain.CastMember{{Actor: actor, Character: "villain", Billing: 1}}, version)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, revisionCount())
+
+	_, err = q.RemoveActorFromMovie(ctx, actor.Id, movie.Id, version)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, revisionCount())
+
+	revision, err := q.GetRevision(ctx, domain.AuditMovie, movie.Id, 3)
+	if assert.NoError(t, err) && assert.Len(t, revision.Movie.Actors, 1) {
+		assert.Equal(t, actor.Id, revision.Movie.Actors[0].Id)
+		assert.Equal(t, "villain", revision.Movie.Actors[0].Character)
+	}
+}
+
+func TestStaleVersionIsRejected(t *testing.T) {
+	q := requireDB(t)
+	ctx := context.Background()
+	date := time.Date(1999, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	movie, err := q.AddMovie(ctx, "stale version", benchTag, date, 5, nil, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	t.Cleanup(func() {
+		_, _ = benchPool.Exec(ctx, deleteRevisionsQuery, string(domain.AuditMovie), movie.Id)
+	})
+	stale := movie.Version
+
+	_, err = q.ReplaceCast(ctx, movie.Id, nil, stale)
+	assert.NoError(t, err)
+
+	_, err = q.ReplaceCast(ctx, movie.Id, nil, stale)
+	assert.ErrorIs(t, err, domain.ErrVersionMismatch)
+	assert.ErrorIs(t, q.UpdateMovie(ctx, &domain.Movie{Id: movie.Id, Title: "t", Description: "d", ReleaseDate: date, Version: stale}), domain.ErrVersionMismatch)
+	assert.ErrorIs(t, q.DeleteMovie(ctx, movie.Id, stale), domain.ErrVersionMismatch)
+
+	// a movie in the trash is gone rather than changed
+	current, err := q.GetMovieById(ctx, movie.Id)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, q.DeleteMovie(ctx, movie.Id, current.Version))
+	assert.ErrorIs(t, q.UpdateMovie(ctx, &domain.Movie{Id: movie.Id, Title: "t", Description: "d", ReleaseDate: date, Version: current.Version + 1}), domain.ErrMovieNotExists)
+	assert.ErrorIs(t, q.DeleteMovie(ctx, movie.Id, current.Version+1), domain.ErrMovieNotExists)
+}
+
+func TestTrashedCreditsAreBilledAfterCast(t *testing.T) {
+	q := requireDB(t)
+	ctx := context.Background()
+	date := time.Date(1999, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	var actors []*domain.Actor
+	for _, name := range []string{"lead", "trashed", "replacement"} {
+		actor, err := q.AddActor(ctx, benchTag+" billing "+name, 1, date)
+		if !assert.NoError(t, err) {
+			return
+		}
+		actors = append(actors, actor)
+	}
+	lead, trashed, replacement := actors[0], actors[1], actors[2]
+	movie, err := q.AddMovie(ctx, "trashed credits", benchTag, date, 5, []*domain.CastMember{
+		{Actor: lead, Billing: 1},
+		{Actor: trashed, Billing: 2},
+	}, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	t.Cleanup(func() {
+		_, _ = benchPool.Exec(ctx, deleteRevisionsQuery, string(domain.AuditMovie), movie.Id)
+	})
+
+	// the billing of the trashed actor is free for the cast
+	if !assert.NoError(t, q.DeleteActor(ctx, trashed.Id, trashed.Version)) {
+		return
+	}
+	version, err := q.AddActorToMovie(ctx, replacement.Id, movie.Id, "", 2, movie.Version)
+	assert.NoError(t, err)
+	_, err = q.ReplaceCast(ctx, movie.Id, []*domain.CastMember{{Actor: replacement, Billing: 1}, {Actor: lead, Billing: 2}}, version)
+	assert.NoError(t, err)
+
+	restored, err := q.RestoreActor(ctx, trashed.Id)
+	if !assert.NoError(t, err) || !assert.True(t, restored) {
+		return
+	}
+	current, err := q.GetMovieById(ctx, movie.Id)
+	if !assert.NoError(t, err) {
+		return
+	}
+	billings := map[int]int{}
+	for _, member := range current.Actors {
+		billings[member.Id] = member.Billing
+	}
+	assert.Equal(t, map[int]int{replacement.Id: 1, lead.Id: 2, trashed.Id: 3}, billings)
+}
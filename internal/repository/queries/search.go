@@ -0,0 +1,72 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"strconv"
+)
+
+// thresholds of pg_trgm word similarity (from 0 to 1) between a search term and a title or a name
+const (
+	fuzzyMatchThreshold = 0.5
+	suggestionThreshold = 0.3
+)
+
+const setWordSimilarityThresholdQuery = `SELECT set_config('pg_trgm.word_similarity_threshold', $1, true)`
+
+// withWordSimilarityThreshold runs fn in a read-only transaction, where the trigram operator <%
+// matches strings with word similarity of at least threshold. Unlike similarity functions the operator
+// is served by trigram indexes.
+func (q *Queries) withWordSimilarityThreshold(ctx context.Context, threshold float64, fn func(tx pgx.Tx) error) error {
+	tx, err := q.pool.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, setWordSimilarityThresholdQuery, strconv.FormatFloat(threshold, 'f', -1, 64)); err != nil {
+		_ = tx.Rollback(ctx)
+		return fmt.Errorf("failed to set similarity threshold: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback(ctx)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+const suggestSpellingsQuery = `
+SELECT suggestion
+FROM (SELECT title AS suggestion, word_similarity($1, title) AS score FROM movies WHERE $1 <% title AND deleted_at IS NULL
+      UNION
+      SELECT name, word_similarity($1, name) FROM actors WHERE $1 <% name AND deleted_at IS NULL) s
+ORDER BY score DESC, suggestion
+LIMIT $2
+`
+
+// SuggestSpellings returns movie titles and actor names closest to the term
+func (q *Queries) SuggestSpellings(ctx context.Context, term string, limit int) ([]string, error) {
+	var suggestions []string
+	err := q.withWordSimilarityThreshold(ctx, suggestionThreshold, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, suggestSpellingsQuery, term, limit)
+		if err != nil {
+			return fmt.Errorf("failed to select suggestions: %w", err)
+		}
+		suggestions, err = pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil {
+			return fmt.Errorf("failed to select suggestions: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return suggestions, nil
+}
@@ -0,0 +1,140 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"vk-backend/internal/domain"
+)
+
+const moviesByYearQuery = `
+SELECT date_part('year', release_date)::int AS year, count(*)::int, avg(rating)::float8, avg(score)::float8
+FROM movies
+WHERE deleted_at IS NULL
+GROUP BY year
+ORDER BY year
+`
+
+// MoviesByYear returns the number of movies and their average ratings for every year a movie was released in
+func (q *Queries) MoviesByYear(ctx context.Context) ([]*domain.YearStats, error) {
+	rows, err := q.pool.Query(ctx, moviesByYearQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get movies by year: %w", err)
+	}
+	defer rows.Close()
+
+	var years []*domain.YearStats
+	for rows.Next() {
+		year := &domain.YearStats{}
+		if err := rows.Scan(&year.Year, &year.MovieCount, &year.AverageRating, &year.AverageScore); err != nil {
+			return nil, fmt.Errorf("failed to get movies by year: %w", err)
+		}
+		years = append(years, year)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("failed to get movies by year: %w", rows.Err())
+	}
+
+	return years, nil
+}
+
+// scores from 0 to 10 fall into buckets one point wide, 10 goes to the last one
+const ratingHistogramQuery = `
+SELECT b.bucket, count(m.id)::int
+FROM generate_series(0, $1 - 1) b(bucket)
+LEFT JOIN movies m ON least(floor(m.score * $1 / 10)::int, $1 - 1) = b.bucket AND m.deleted_at IS NULL
+GROUP BY b.bucket
+ORDER BY b.bucket
+`
+
+// RatingHistogram counts movies by score in buckets of equal width, empty buckets are included
+func (q *Queries) RatingHistogram(ctx context.Context, buckets int) ([]*domain.RatingBucket, error) {
+	rows, err := q.pool.Query(ctx, ratingHistogramQuery, buckets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rating histogram: %w", err)
+	}
+	defer rows.Close()
+
+	width := 10 / float64(buckets)
+	var histogram []*domain.RatingBucket
+	for rows.Next() {
+		var i int
+		bucket := &domain.RatingBucket{}
+		if err := rows.Scan(&i, &bucket.MovieCount); err != nil {
+			return nil, fmt.Errorf("failed to get rating histogram: %w", err)
+		}
+		bucket.From, bucket.To = float64(i)*width, float64(i+1)*width
+		histogram = append(histogram, bucket)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("failed to get rating histogram: %w", rows.Err())
+	}
+
+	return histogram, nil
+}
+
+const prolificActorsQuery = `
+SELECT a.id, a.name, a.gender, a.birth_date, count(*)::int AS movies, min(m.release_date), max(m.release_date)
+FROM movie_actors ma
+JOIN actors a ON a.id = ma.actor_id AND a.deleted_at IS NULL
+JOIN movies m ON m.id = ma.movie_id AND m.deleted_at IS NULL
+GROUP BY a.id
+ORDER BY movies DESC, a.id
+LIMIT $1
+`
+
+// ProlificActors returns actors who played in the most movies
+func (q *Queries) ProlificActors(ctx context.Context, limit int) ([]*domain.ProlificActor, error) {
+	rows, err := q.pool.Query(ctx, prolificActorsQuery, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prolific actors: %w", err)
+	}
+	defer rows.Close()
+
+	var actors []*domain.ProlificActor
+	for rows.Next() {
+		actor := &domain.ProlificActor{Actor: &domain.Actor{}}
+		if err := rows.Scan(&actor.Id, &actor.Name, &actor.Gender, &actor.BirthDate, &actor.Actor.MovieCount, &actor.FirstRelease, &actor.LastRelease); err != nil {
+			return nil, fmt.Errorf("failed to get prolific actors: %w", err)
+		}
+		actors = append(actors, actor)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("failed to get prolific actors: %w", rows.Err())
+	}
+
+	return actors, nil
+}
+
+// roles in movies released before the actor was born are data errors and don't count
+const actorAgesQuery = `
+SELECT date_part('year', age(m.release_date, a.birth_date))::int AS age, count(*)::int
+FROM movie_actors ma
+JOIN actors a ON a.id = ma.actor_id AND a.deleted_at IS NULL
+JOIN movies m ON m.id = ma.movie_id AND m.deleted_at IS NULL
+WHERE m.release_date >= a.birth_date AND ($1::int IS NULL OR ma.actor_id = $1)
+GROUP BY age
+ORDER BY age
+`
+
+// ActorAges counts roles by the age of actors at the release of the movie, nil actor counts roles of all the actors
+func (q *Queries) ActorAges(ctx context.Context, actorId *int) ([]domain.AgeCount, error) {
+	rows, err := q.pool.Query(ctx, actorAgesQuery, actorId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get actor ages: %w", err)
+	}
+	defer rows.Close()
+
+	var ages []domain.AgeCount
+	for rows.Next() {
+		var age domain.AgeCount
+		if err := rows.Scan(&age.Age, &age.Roles); err != nil {
+			return nil, fmt.Errorf("failed to get actor ages: %w", err)
+		}
+		ages = append(ages, age)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("failed to get actor ages: %w", rows.Err())
+	}
+
+	return ages, nil
+}
@@ -0,0 +1,175 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"time"
+	"vk-backend/internal/domain"
+)
+
+const listDeletedMoviesQuery = `
+SELECT m.id, m.title, m.description, m.release_date, m.rating, m.score, m.review_count, m.deleted_at
+FROM movies m
+`
+
+// deletedMovieOrder lists the most recently deleted movies first
+var deletedMovieOrder = keyset[*domain.Movie]{name: "deleted", keys: []sortKey[*domain.Movie]{
+	{
+		expr:  "m.deleted_at",
+		typ:   "timestamptz",
+		desc:  true,
+		value: func(m *domain.Movie) string { return m.DeletedAt.Format(time.RFC3339Nano) },
+	},
+	movieIdKey,
+}}
+
+// ListDeletedMovies returns a page of movies in the trash with their casts and genres
+// and a cursor of the next page, which is empty for the last one
+func (q *Queries) ListDeletedMovies(ctx context.Context, page domain.Page) ([]*domain.Movie, string, error) {
+	b := &queryBuilder{}
+	b.where("m.deleted_at IS NOT NULL")
+	if err := deletedMovieOrder.after(b, page.Cursor); err != nil {
+		return nil, "", err
+	}
+	query := fmt.Sprintf("%s %s ORDER BY %s LIMIT %s", listDeletedMoviesQuery, b.whereClause(), deletedMovieOrder.orderBy(), b.arg(page.Limit+1))
+
+	rows, err := q.pool.Query(ctx, query, b.args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list deleted movies: %w", err)
+	}
+	defer rows.Close()
+
+	var movies []*domain.Movie
+	for rows.Next() {
+		movie := &domain.Movie{}
+		if err := rows.Scan(&movie.Id, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating, &movie.Score, &movie.ReviewCount, &movie.DeletedAt); err != nil {
+			return nil, "", fmt.Errorf("failed to list deleted movies: %w", err)
+		}
+		movies = append(movies, movie)
+	}
+	if rows.Err() != nil {
+		return nil, "", fmt.Errorf("failed to list deleted movies: %w", rows.Err())
+	}
+
+	movies, next := paginate(movies, page.Limit, deletedMovieOrder)
+
+	if err := loadCasts(ctx, q.pool, movies); err != nil {
+		return nil, "", fmt.Errorf("failed to list deleted movies: %w", err)
+	}
+	if err := loadGenres(ctx, q.pool, movies); err != nil {
+		return nil, "", fmt.Errorf("failed to list deleted movies: %w", err)
+	}
+
+	return movies, next, nil
+}
+
+const restoreMovieQuery = `UPDATE movies SET deleted_at = NULL, version = version + 1, updated_at = now() WHERE id = $1 AND deleted_at IS NOT NULL`
+
+// RestoreMovie takes the movie out of the trash, it returns false if the movie isn't there
+func (q *Queries) RestoreMovie(ctx context.Context, id int) (bool, error) {
+	return q.audited(ctx, domain.AuditRestore, domain.AuditMovie, id, func(tx pgx.Tx) (bool, error) {
+		tag, err := tx.Exec(ctx, restoreMovieQuery, id)
+		if err != nil {
+			return false, fmt.Errorf("failed to restore movie: %w", err)
+		}
+		return tag.RowsAffected() > 0, nil
+	})
+}
+
+const purgeMovieQuery = `DELETE FROM movies WHERE id = $1 AND deleted_at IS NOT NULL`
+
+// PurgeMovie deletes the movie in the trash for good along with its cast and revisions, it returns false if the movie isn't there
+func (q *Queries) PurgeMovie(ctx context.Context, id int) (bool, error) {
+	return q.audited(ctx, domain.AuditPurge, domain.AuditMovie, id, func(tx pgx.Tx) (bool, error) {
+		tag, err := tx.Exec(ctx, purgeMovieQuery, id)
+		if err != nil {
+			return false, fmt.Errorf("failed to purge movie: %w", err)
+		}
+		if _, err := tx.Exec(ctx, deleteRevisionsQuery, string(domain.AuditMovie), id); err != nil {
+			return false, fmt.Errorf("failed to delete movie revisions: %w", err)
+		}
+		return tag.RowsAffected() > 0, nil
+	})
+}
+
+const listDeletedActorsQuery = `
+SELECT a.id, a.name, a.gender, a.birth_date, a.deleted_at
+FROM actors a
+`
+
+// deletedActorOrder lists the most recently deleted actors first
+var deletedActorOrder = keyset[*domain.Actor]{name: "deleted", keys: []sortKey[*domain.Actor]{
+	{
+		expr:  "a.deleted_at",
+		typ:   "timestamptz",
+		desc:  true,
+		value: func(a *domain.Actor) string { return a.DeletedAt.Format(time.RFC3339Nano) },
+	},
+	actorIdKey,
+}}
+
+// ListDeletedActors returns a page of actors in the trash and a cursor of the next page, which is empty for the last one
+func (q *Queries) ListDeletedActors(ctx context.Context, page domain.Page) ([]*domain.Actor, string, error) {
+	b := &queryBuilder{}
+	b.where("a.deleted_at IS NOT NULL")
+	if err := deletedActorOrder.after(b, page.Cursor); err != nil {
+		return nil, "", err
+	}
+	query := fmt.Sprintf("%s %s ORDER BY %s LIMIT %s", listDeletedActorsQuery, b.whereClause(), deletedActorOrder.orderBy(), b.arg(page.Limit+1))
+
+	rows, err := q.pool.Query(ctx, query, b.args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list deleted actors: %w", err)
+	}
+	defer rows.Close()
+
+	var actors []*domain.Actor
+	for rows.Next() {
+		actor := &domain.Actor{}
+		if err := rows.Scan(&actor.Id, &actor.Name, &actor.Gender, &actor.BirthDate, &actor.DeletedAt); err != nil {
+			return nil, "", fmt.Errorf("failed to list deleted actors: %w", err)
+		}
+		actors = append(actors, actor)
+	}
+	if rows.Err() != nil {
+		return nil, "", fmt.Errorf("failed to list deleted actors: %w", rows.Err())
+	}
+
+	actors, next := paginate(actors, page.Limit, deletedActorOrder)
+
+	return actors, next, nil
+}
+
+const restoreActorQuery = `UPDATE actors SET deleted_at = NULL, version = version + 1, updated_at = now() WHERE id = $1 AND deleted_at IS NOT NULL`
+
+// RestoreActor takes the actor out of the trash, the actor is back in the casts of all the movies.
+// It returns false if the actor isn't there.
+func (q *Queries) RestoreActor(ctx context.Context, id int) (bool, error) {
+	return q.audited(ctx, domain.AuditRestore, domain.AuditActor, id, func(tx pgx.Tx) (bool, error) {
+		tag, err := tx.Exec(ctx, restoreActorQuery, id)
+		if err != nil {
+			return false, fmt.Errorf("failed to restore actor: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return false, nil
+		}
+		return true, touchActorMovies(ctx, tx, id)
+	})
+}
+
+const purgeActorQuery = `DELETE FROM actors WHERE id = $1 AND deleted_at IS NOT NULL`
+
+// PurgeActor deletes the actor in the trash for good along with the credits and revisions, it returns false if the actor isn't there
+func (q *Queries) PurgeActor(ctx context.Context, id int) (bool, error) {
+	return q.audited(ctx, domain.AuditPurge, domain.AuditActor, id, func(tx pgx.Tx) (bool, error) {
+		tag, err := tx.Exec(ctx, purgeActorQuery, id)
+		if err != nil {
+			return false, fmt.Errorf("failed to purge actor: %w", err)
+		}
+		if _, err := tx.Exec(ctx, deleteRevisionsQuery, string(domain.AuditActor), id); err != nil {
+			return false, fmt.Errorf("failed to delete actor revisions: %w", err)
+		}
+		return tag.RowsAffected() > 0, nil
+	})
+}
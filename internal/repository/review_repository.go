@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+	"vk-backend/internal/domain"
+	"vk-backend/internal/repository/queries"
+)
+
+type ReviewRepository interface {
+	SaveReview(ctx context.Context, movieId int, userId int, rating int, text string) (*domain.Review, error)
+	GetReview(ctx context.Context, movieId int, userId int) (*domain.Review, error)
+	ListReviews(ctx context.Context, movieId int, page domain.Page) ([]*domain.Review, string, error)
+	DeleteReview(ctx context.Context, movieId int, userId int) error
+
+	MovieExists(ctx context.Context, id int) (bool, error)
+}
+
+type reviewRepo struct {
+	*queries.Queries
+	pool   *pgxpool.Pool
+	logger logrus.FieldLogger
+}
+
+func NewReviewRepository(pool *pgxpool.Pool, logger logrus.FieldLogger) ReviewRepository {
+	return &reviewRepo{
+		Queries: queries.NewQueries(pool),
+		pool:    pool,
+		logger:  logger,
+	}
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+	"vk-backend/internal/domain"
+	"vk-backend/internal/repository/queries"
+)
+
+type StatsRepository interface {
+	MoviesByYear(ctx context.Context) ([]*domain.YearStats, error)
+	RatingHistogram(ctx context.Context, buckets int) ([]*domain.RatingBucket, error)
+	ProlificActors(ctx context.Context, limit int) ([]*domain.ProlificActor, error)
+	ActorAges(ctx context.Context, actorId *int) ([]domain.AgeCount, error)
+
+	ActorExists(ctx context.Context, id int) (bool, error)
+}
+
+type statsRepo struct {
+	*queries.Queries
+	pool   *pgxpool.Pool
+	logger logrus.FieldLogger
+}
+
+func NewStatsRepository(pool *pgxpool.Pool, logger logrus.FieldLogger) StatsRepository {
+	return &statsRepo{
+		Queries: queries.NewQueries(pool),
+		pool:    pool,
+		logger:  logger,
+	}
+}
@@ -13,9 +13,32 @@ type ActorService interface {
 	GetActorById(ctx context.Context, id int) (*domain.Actor, error)
 
 	UpdateActor(ctx context.Context, new *domain.Actor) error
-	DeleteActor(ctx context.Context, id int) error
-
-	ListActors(ctx context.Context) ([]*domain.Actor, error)
+	// DeleteActor moves the actor to the trash unless it was changed since the version
+	DeleteActor(ctx context.Context, id int, version int) error
+
+	// ListDeletedActors returns a page of actors in the trash, the most recently deleted first
+	ListDeletedActors(ctx context.Context, page domain.Page) ([]*domain.Actor, string, error)
+	RestoreActor(ctx context.Context, id int) error
+	// PurgeActor deletes the actor in the trash for good, actors must be deleted before they can be purged
+	PurgeActor(ctx context.Context, id int) error
+
+	// ListRevisions returns a page of revisions of the actor without their data, the latest first
+	ListRevisions(ctx context.Context, actorId int, page domain.Page) ([]*domain.Revision, string, error)
+	GetRevision(ctx context.Context, actorId int, number int) (*domain.Revision, error)
+	// RestoreRevision updates the actor of the given version to the state of the revision and returns the new version
+	RestoreRevision(ctx context.Context, actorId int, number int, version int) (int, error)
+	// DiffRevisions returns fields changed from one revision to another
+	DiffRevisions(ctx context.Context, actorId int, from int, to int) ([]domain.FieldChange, error)
+
+	ListActors(ctx context.Context, filter *Filter, sorting Sorting, page domain.Page, withMovies bool) ([]*domain.Actor, string, error)
+
+	ListCostars(ctx context.Context, actorId int, page domain.Page) ([]*domain.Costar, string, error)
+	// ShortestPath finds how actors are connected through the movies they played in, zero depth means DefaultPathDepth
+	ShortestPath(ctx context.Context, from int, to int, depth int) (*domain.ActorPath, error)
+
+	// ExportActors streams every actor to fn, including the ones that aren't in any cast,
+	// it stops at the first error of fn
+	ExportActors(ctx context.Context, fn func(*domain.Actor) error) error
 }
 
 type actorService struct {
@@ -28,7 +51,7 @@ func NewService(repo repository.ActorRepository) ActorService {
 	}
 }
 func (s *actorService) AddActor(ctx context.Context, name string, gender int, birthDate time.Time) (*domain.Actor, error) {
-	err := validateActorData(name, birthDate, gender)
+	err := ValidateActorData(name, birthDate, gender)
 	if err != nil {
 		return nil, err
 	}
@@ -73,7 +96,7 @@ func (s *actorService) UpdateActor(ctx context.Context, new *domain.Actor) error
 		return domain.ErrActorNotExists
 	}
 
-	err = validateActorData(new.Name, new.BirthDate, new.Gender)
+	err = ValidateActorData(new.Name, new.BirthDate, new.Gender)
 	if err != nil {
 		return err
 	}
@@ -86,7 +109,7 @@ func (s *actorService) UpdateActor(ctx context.Context, new *domain.Actor) error
 	return nil
 }
 
-func (s *actorService) DeleteActor(ctx context.Context, id int) error {
+func (s *actorService) DeleteActor(ctx context.Context, id int, version int) error {
 	if id <= 0 {
 		return domain.ErrActorNotExists
 	}
@@ -98,7 +121,7 @@ func (s *actorService) DeleteActor(ctx context.Context, id int) error {
 		return domain.ErrActorNotExists
 	}
 
-	err = s.repo.DeleteActor(ctx, id)
+	err = s.repo.DeleteActor(ctx, id, version)
 	if err != nil {
 		return fmt.Errorf("actor service can't delete actor: %w", err)
 	}
@@ -106,17 +129,68 @@ func (s *actorService) DeleteActor(ctx context.Context, id int) error {
 	return nil
 }
 
-func (s *actorService) ListActors(ctx context.Context) ([]*domain.Actor, error) {
-	actors, err := s.repo.ListActors(ctx)
+// ListActors returns a page of actors matching the filter, withMovies loads movies of every actor on the page at once
+func (s *actorService) ListActors(ctx context.Context, filter *Filter, sorting Sorting, page domain.Page, withMovies bool) ([]*domain.Actor, string, error) {
+	if err := filter.validate(); err != nil {
+		return nil, "", err
+	}
+	if err := validateSorting(sorting); err != nil {
+		return nil, "", err
+	}
+
+	actors, next, err := s.repo.ListActors(ctx, filter.params(), sorting, page.Normalize())
 	if err != nil {
-		return nil, fmt.Errorf("actor service can't list actors: %w", err)
+		return nil, "", fmt.Errorf("actor service can't list actors: %w", err)
+	}
+	if withMovies {
+		if err := s.repo.LoadFilmographies(ctx, actors); err != nil {
+			return nil, "", fmt.Errorf("actor service can't load filmographies: %w", err)
+		}
 	}
 
-	return actors, nil
+	return actors, next, nil
 
 }
 
-func validateActorData(name string, birthDate time.Time, gender int) error {
+// ListCostars returns a page of actors who played with the given one, the most frequent costars go first
+func (s *actorService) ListCostars(ctx context.Context, actorId int, page domain.Page) ([]*domain.Costar, string, error) {
+	if err := s.checkActor(ctx, actorId); err != nil {
+		return nil, "", err
+	}
+
+	costars, next, err := s.repo.ListCostars(ctx, actorId, page.Normalize())
+	if err != nil {
+		return nil, "", fmt.Errorf("actor service can't list costars: %w", err)
+	}
+
+	return costars, next, nil
+}
+
+func (s *actorService) checkActor(ctx context.Context, id int) error {
+	if id <= 0 {
+		return domain.ErrActorNotExists
+	}
+	ok, err := s.repo.ActorExists(ctx, id)
+	if err != nil {
+		return fmt.Errorf("actor service can't check if actor exists: %w", err)
+	}
+	if !ok {
+		return domain.ErrActorNotExists
+	}
+
+	return nil
+}
+
+func (s *actorService) ExportActors(ctx context.Context, fn func(*domain.Actor) error) error {
+	if err := s.repo.ExportActors(ctx, fn); err != nil {
+		return fmt.Errorf("actor service can't export actors: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateActorData checks the actor the same way for the API and bulk imports
+func ValidateActorData(name string, birthDate time.Time, gender int) error {
 	if name == "" {
 		return domain.ErrEmptyName
 	}
@@ -126,9 +200,14 @@ func validateActorData(name string, birthDate time.Time, gender int) error {
 	if birthDate.IsZero() {
 		return domain.ErrEmptyBirthDate
 	}
-	if gender != 0 && gender != 1 && gender != 2 && gender != 9 {
+	if !validGender(gender) {
 		return domain.ErrInvalidGender
 	}
 
 	return nil
 }
+
+// validGender tells if the gender is one of the ISO/IEC 5218 codes
+func validGender(gender int) bool {
+	return gender == 0 || gender == 1 || gender == 2 || gender == 9
+}
@@ -2,6 +2,7 @@ package actor
 
 import (
 	"context"
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"testing"
@@ -178,10 +179,10 @@ func TestActorService_DeleteActor(t *testing.T) {
 
 	repo.
 		EXPECT().
-		DeleteActor(gomock.Any(), 1).
+		DeleteActor(gomock.Any(), 1, 2).
 		Return(nil)
 
-	err := service.DeleteActor(context.Background(), 1)
+	err := service.DeleteActor(context.Background(), 1, 2)
 	assert.NoError(t, err)
 }
 
@@ -197,7 +198,7 @@ func TestActorService_DeleteActor_NotExists(t *testing.T) {
 		ActorExists(gomock.Any(), 1).
 		Return(false, nil)
 
-	err := service.DeleteActor(context.Background(), 1)
+	err := service.DeleteActor(context.Background(), 1, 2)
 	assert.ErrorIs(t, err, domain.ErrActorNotExists)
 }
 
@@ -260,9 +261,157 @@ func TestActorService_RepoReturnsInnerError(t *testing.T) {
 
 	repo.
 		EXPECT().
-		DeleteActor(gomock.Any(), 1).
+		DeleteActor(gomock.Any(), 1, 2).
 		Return(assert.AnError)
 
-	err = service.DeleteActor(context.Background(), 1)
+	err = service.DeleteActor(context.Background(), 1, 2)
 	assert.ErrorIs(t, err, assert.AnError)
 }
+
+func TestActorService_ListActors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockActorRepository(ctrl)
+	service := NewService(repo)
+
+	birthDate := time.Now()
+	expected := []*domain.Actor{
+		{
+			Id:        1,
+			Name:      "name",
+			Gender:    1,
+			BirthDate: birthDate,
+		},
+	}
+	repo.
+		EXPECT().
+		ListActors(gomock.Any(), domain.ActorFilter{}, nil, domain.Page{Limit: 1, Cursor: "cursor"}).
+		Return(expected, "next", nil)
+
+	actors, next, err := service.ListActors(context.Background(), nil, nil, domain.Page{Limit: 1, Cursor: "cursor"}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actors)
+	assert.Equal(t, "next", next)
+}
+
+func TestActorService_ListActors_DefaultLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockActorRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		ListActors(gomock.Any(), domain.ActorFilter{}, nil, domain.Page{Limit: domain.DefaultPageLimit}).
+		Return(nil, "", nil)
+
+	actors, next, err := service.ListActors(context.Background(), nil, nil, domain.Page{}, false)
+	assert.NoError(t, err)
+	assert.Empty(t, actors)
+	assert.Empty(t, next)
+}
+
+func TestActorService_ListActors_WithMovies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockActorRepository(ctrl)
+	service := NewService(repo)
+
+	expected := []*domain.Actor{{Id: 1, Name: "name"}, {Id: 2, Name: "other"}}
+	repo.
+		EXPECT().
+		ListActors(gomock.Any(), domain.ActorFilter{}, nil, domain.Page{Limit: domain.DefaultPageLimit}).
+		Return(expected, "", nil)
+
+	repo.
+		EXPECT().
+		LoadFilmographies(gomock.Any(), expected).
+		DoAndReturn(func(_ context.Context, actors []*domain.Actor) error {
+			actors[0].Movies = []*domain.Role{{Movie: &domain.Movie{Id: 1}, Character: "character", Billing: 1}}
+			return nil
+		})
+
+	actors, _, err := service.ListActors(context.Background(), nil, nil, domain.Page{}, true)
+	assert.NoError(t, err)
+	assert.Len(t, actors[0].Movies, 1)
+	assert.Empty(t, actors[1].Movies)
+}
+
+func TestActorService_ListActors_WithFilterAndSorting(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockActorRepository(ctrl)
+	service := NewService(repo)
+
+	name, gender := "name", 2
+	from := time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+	sorting := Sorting{NewSortKey(SortByMovieCount), NewSortKey(SortByName)}
+	repo.
+		EXPECT().
+		ListActors(gomock.Any(), domain.ActorFilter{Name: &name, Gender: &gender, BirthDateFrom: &from}, sorting, domain.Page{Limit: domain.DefaultPageLimit}).
+		Return([]*domain.Actor{{Id: 1, Name: name, MovieCount: 3}}, "", nil)
+
+	filter := NewFilter().WithName(name).WithGender(gender).WithBirthDateFrom(from)
+	actors, _, err := service.ListActors(context.Background(), filter, sorting, domain.Page{}, false)
+	assert.NoError(t, err)
+	assert.Len(t, actors, 1)
+	assert.True(t, sorting[0].Desc)
+	assert.False(t, sorting[1].Desc)
+}
+
+func TestActorService_ListActors_InvalidParams(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockActorRepository(ctrl)
+	service := NewService(repo)
+
+	_, _, err := service.ListActors(context.Background(), NewFilter().WithGender(5), nil, domain.Page{}, false)
+	assert.ErrorIs(t, err, domain.ErrInvalidGender)
+
+	from, to := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, _, err = service.ListActors(context.Background(), NewFilter().WithBirthDateFrom(from).WithBirthDateTo(to), nil, domain.Page{}, false)
+	assert.ErrorIs(t, err, domain.ErrInvalidDateRange)
+
+	sorting := Sorting{NewSortKey(SortByName), NewSortKey(SortByName)}
+	_, _, err = service.ListActors(context.Background(), nil, sorting, domain.Page{}, false)
+	assert.ErrorIs(t, err, domain.ErrInvalidSort)
+}
+
+func TestActorService_ExportActors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockActorRepository(ctrl)
+	service := NewService(repo)
+
+	actors := []*domain.Actor{{Id: 1, Name: "cast"}, {Id: 2, Name: "not in any cast"}}
+	stop := errors.New("client has gone")
+	repo.
+		EXPECT().
+		ExportActors(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, fn func(*domain.Actor) error) error {
+			for _, a := range actors {
+				if err := fn(a); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).
+		Times(2)
+
+	var exported []int
+	err := service.ExportActors(context.Background(), func(a *domain.Actor) error {
+		exported = append(exported, a.Id)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, exported)
+
+	err = service.ExportActors(context.Background(), func(a *domain.Actor) error { return stop })
+	assert.ErrorIs(t, err, stop)
+}
@@ -0,0 +1,68 @@
+package actor
+
+import (
+	"time"
+	"vk-backend/internal/domain"
+)
+
+type Filter struct {
+	name          *string
+	gender        *int
+	birthDateFrom *time.Time
+	birthDateTo   *time.Time
+}
+
+func NewFilter() *Filter {
+	return &Filter{}
+}
+
+// WithName searches actors by a substring of the name, slightly misspelled names are matched too
+func (f *Filter) WithName(name string) *Filter {
+	f.name = &name
+	return f
+}
+
+func (f *Filter) WithGender(gender int) *Filter {
+	f.gender = &gender
+	return f
+}
+
+// WithBirthDateFrom keeps actors born on or after the date
+func (f *Filter) WithBirthDateFrom(birthDate time.Time) *Filter {
+	f.birthDateFrom = &birthDate
+	return f
+}
+
+// WithBirthDateTo keeps actors born on or before the date
+func (f *Filter) WithBirthDateTo(birthDate time.Time) *Filter {
+	f.birthDateTo = &birthDate
+	return f
+}
+
+func (f *Filter) validate() error {
+	if f == nil {
+		return nil
+	}
+	if f.gender != nil && !validGender(*f.gender) {
+		return domain.ErrInvalidGender
+	}
+	if f.birthDateFrom != nil && f.birthDateTo != nil && f.birthDateFrom.After(*f.birthDateTo) {
+		return domain.ErrInvalidDateRange
+	}
+
+	return nil
+}
+
+// params converts the filter to the form the repository applies in SQL, nil filter matches every actor
+func (f *Filter) params() domain.ActorFilter {
+	if f == nil {
+		return domain.ActorFilter{}
+	}
+
+	return domain.ActorFilter{
+		Name:          f.name,
+		Gender:        f.gender,
+		BirthDateFrom: f.birthDateFrom,
+		BirthDateTo:   f.birthDateTo,
+	}
+}
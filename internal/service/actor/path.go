@@ -0,0 +1,128 @@
+package actor
+
+import (
+	"context"
+	"fmt"
+	"vk-backend/internal/domain"
+)
+
+const (
+	DefaultPathDepth = 6 // six degrees of separation
+	MaxPathDepth     = 10
+)
+
+// bfsSide is a half of the bidirectional search, parent links lead back to the actor the side starts from
+type bfsSide struct {
+	parent   map[int]domain.CostarLink
+	dist     map[int]int
+	frontier []int
+}
+
+func newBfsSide(start int) *bfsSide {
+	return &bfsSide{
+		parent:   map[int]domain.CostarLink{},
+		dist:     map[int]int{start: 0},
+		frontier: []int{start},
+	}
+}
+
+// ShortestPath finds the shortest chain of costars from one actor to another, which is at most depth movies long.
+// The search goes from both ends, each step expands the smaller frontier with a single query.
+func (s *actorService) ShortestPath(ctx context.Context, from int, to int, depth int) (*domain.ActorPath, error) {
+	if depth == 0 {
+		depth = DefaultPathDepth
+	}
+	if depth < 0 || depth > MaxPathDepth {
+		return nil, domain.ErrInvalidDepth
+	}
+	for _, id := range []int{from, to} {
+		if err := s.checkActor(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	if from == to {
+		return s.loadPath(ctx, []int{from}, nil)
+	}
+
+	fwd, bwd := newBfsSide(from), newBfsSide(to)
+	for step := 0; step < depth && len(fwd.frontier) > 0 && len(bwd.frontier) > 0; step++ {
+		side, other := fwd, bwd
+		if len(bwd.frontier) < len(fwd.frontier) {
+			side, other = bwd, fwd
+		}
+
+		links, err := s.repo.ExpandCostars(ctx, side.frontier)
+		if err != nil {
+			return nil, fmt.Errorf("actor service can't expand costars: %w", err)
+		}
+
+		// the meeting actor closest to the other end gives the shortest path
+		meet, next := -1, make([]int, 0, len(links))
+		for _, link := range links {
+			if _, seen := side.dist[link.To]; seen {
+				continue
+			}
+			side.dist[link.To] = side.dist[link.From] + 1
+			side.parent[link.To] = link
+			next = append(next, link.To)
+
+			if d, ok := other.dist[link.To]; ok && (meet < 0 || d < other.dist[meet]) {
+				meet = link.To
+			}
+		}
+		if meet >= 0 {
+			actors, movies := joinPath(fwd, bwd, meet, from, to)
+			return s.loadPath(ctx, actors, movies)
+		}
+		side.frontier = next
+	}
+
+	return nil, domain.ErrPathNotFound
+}
+
+// joinPath walks parent links from the meeting actor to both ends
+func joinPath(fwd, bwd *bfsSide, meet int, from int, to int) ([]int, []int) {
+	var actors, movies []int
+	for id := meet; id != from; id = fwd.parent[id].From {
+		actors = append(actors, id)
+		movies = append(movies, fwd.parent[id].MovieId)
+	}
+	actors = append(actors, from)
+	for i, j := 0, len(actors)-1; i < j; i, j = i+1, j-1 {
+		actors[i], actors[j] = actors[j], actors[i]
+	}
+	for i, j := 0, len(movies)-1; i < j; i, j = i+1, j-1 {
+		movies[i], movies[j] = movies[j], movies[i]
+	}
+
+	for id := meet; id != to; id = bwd.parent[id].From {
+		actors = append(actors, bwd.parent[id].From)
+		movies = append(movies, bwd.parent[id].MovieId)
+	}
+
+	return actors, movies
+}
+
+func (s *actorService) loadPath(ctx context.Context, actorIds []int, movieIds []int) (*domain.ActorPath, error) {
+	actors, err := s.repo.GetActorsByIds(ctx, actorIds)
+	if err != nil {
+		return nil, fmt.Errorf("actor service can't get actors of the path: %w", err)
+	}
+	path := &domain.ActorPath{Actors: make([]*domain.Actor, 0, len(actorIds)), Movies: make([]*domain.Movie, 0, len(movieIds))}
+	for _, id := range actorIds {
+		path.Actors = append(path.Actors, actors[id])
+	}
+	if len(movieIds) == 0 {
+		return path, nil
+	}
+
+	movies, err := s.repo.GetMoviesByIds(ctx, movieIds)
+	if err != nil {
+		return nil, fmt.Errorf("actor service can't get movies of the path: %w", err)
+	}
+	for _, id := range movieIds {
+		path.Movies = append(path.Movies, movies[id])
+	}
+
+	return path, nil
+}
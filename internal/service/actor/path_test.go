@@ -0,0 +1,156 @@
+package actor
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"vk-backend/internal/domain"
+	"vk-backend/mocks"
+)
+
+// castsGraph is movie id -> ids of actors in its cast
+type castsGraph map[int][]int
+
+// expand links every actor of the frontier to the costars, as the repository does
+func (g castsGraph) expand(_ context.Context, frontier []int) ([]domain.CostarLink, error) {
+	in := map[int]bool{}
+	for _, id := range frontier {
+		in[id] = true
+	}
+	var links []domain.CostarLink
+	reached := map[int]bool{}
+	for movie := 1; movie <= len(g); movie++ {
+		for _, from := range g[movie] {
+			if !in[from] {
+				continue
+			}
+			for _, to := range g[movie] {
+				if !in[to] && !reached[to] {
+					reached[to] = true
+					links = append(links, domain.CostarLink{From: from, To: to, MovieId: movie})
+				}
+			}
+		}
+	}
+	return links, nil
+}
+
+func expectPathLoading(repo *mocks.MockActorRepository) {
+	repo.
+		EXPECT().
+		GetActorsByIds(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, ids []int) (map[int]*domain.Actor, error) {
+			actors := map[int]*domain.Actor{}
+			for _, id := range ids {
+				actors[id] = &domain.Actor{Id: id}
+			}
+			return actors, nil
+		}).
+		AnyTimes()
+	repo.
+		EXPECT().
+		GetMoviesByIds(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, ids []int) (map[int]*domain.Movie, error) {
+			movies := map[int]*domain.Movie{}
+			for _, id := range ids {
+				movies[id] = &domain.Movie{Id: id}
+			}
+			return movies, nil
+		}).
+		AnyTimes()
+}
+
+func pathIds(path *domain.ActorPath) ([]int, []int) {
+	var actors, movies []int
+	for _, a := range path.Actors {
+		actors = append(actors, a.Id)
+	}
+	for _, m := range path.Movies {
+		movies = append(movies, m.Id)
+	}
+	return actors, movies
+}
+
+func TestActorService_ShortestPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockActorRepository(ctrl)
+	service := NewService(repo)
+
+	// 1 -(m1)- 2 -(m2)- 3 -(m3)- 4 -(m4)- 5, and a longer way round 1 -(m5)- 6 -(m6)- 7 -(m7)- 8 -(m8)- 9 -(m9)- 5
+	graph := castsGraph{
+		1: {1, 2}, 2: {2, 3}, 3: {3, 4}, 4: {4, 5},
+		5: {1, 6}, 6: {6, 7}, 7: {7, 8}, 8: {8, 9}, 9: {9, 5},
+	}
+	repo.EXPECT().ActorExists(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	repo.EXPECT().ExpandCostars(gomock.Any(), gomock.Any()).DoAndReturn(graph.expand).AnyTimes()
+	expectPathLoading(repo)
+
+	path, err := service.ShortestPath(context.Background(), 1, 5, 0)
+	assert.NoError(t, err)
+	actors, movies := pathIds(path)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, actors)
+	assert.Equal(t, []int{1, 2, 3, 4}, movies)
+
+	path, err = service.ShortestPath(context.Background(), 5, 1, 0)
+	assert.NoError(t, err)
+	actors, movies = pathIds(path)
+	assert.Equal(t, []int{5, 4, 3, 2, 1}, actors)
+	assert.Equal(t, []int{4, 3, 2, 1}, movies)
+
+	path, err = service.ShortestPath(context.Background(), 2, 2, 0)
+	assert.NoError(t, err)
+	actors, movies = pathIds(path)
+	assert.Equal(t, []int{2}, actors)
+	assert.Empty(t, movies)
+}
+
+func TestActorService_ShortestPath_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockActorRepository(ctrl)
+	service := NewService(repo)
+
+	graph := castsGraph{1: {1, 2}, 2: {2, 3}, 3: {3, 4}, 4: {5, 6}}
+	repo.EXPECT().ActorExists(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	repo.EXPECT().ExpandCostars(gomock.Any(), gomock.Any()).DoAndReturn(graph.expand).AnyTimes()
+	expectPathLoading(repo)
+
+	_, err := service.ShortestPath(context.Background(), 1, 6, 0)
+	assert.ErrorIs(t, err, domain.ErrPathNotFound)
+
+	// 1 and 4 are three movies apart
+	_, err = service.ShortestPath(context.Background(), 1, 4, 2)
+	assert.ErrorIs(t, err, domain.ErrPathNotFound)
+
+	_, err = service.ShortestPath(context.Background(), 1, 4, 3)
+	assert.NoError(t, err)
+
+	_, err = service.ShortestPath(context.Background(), 1, 4, MaxPathDepth+1)
+	assert.ErrorIs(t, err, domain.ErrInvalidDepth)
+}
+
+func TestActorService_ListCostars(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockActorRepository(ctrl)
+	service := NewService(repo)
+
+	expected := []*domain.Costar{{Actor: &domain.Actor{Id: 2}, SharedMovies: 3}}
+	repo.
+		EXPECT().
+		ActorExists(gomock.Any(), 1).
+		Return(true, nil)
+	repo.
+		EXPECT().
+		ListCostars(gomock.Any(), 1, domain.Page{Limit: domain.DefaultPageLimit}).
+		Return(expected, "", nil)
+
+	costars, _, err := service.ListCostars(context.Background(), 1, domain.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, costars)
+}
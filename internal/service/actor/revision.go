@@ -0,0 +1,69 @@
+package actor
+
+import (
+	"context"
+	"fmt"
+	"vk-backend/internal/domain"
+)
+
+func (s *actorService) ListRevisions(ctx context.Context, actorId int, page domain.Page) ([]*domain.Revision, string, error) {
+	if err := s.checkActor(ctx, actorId); err != nil {
+		return nil, "", err
+	}
+
+	revisions, next, err := s.repo.ListRevisions(ctx, domain.AuditActor, actorId, page.Normalize())
+	if err != nil {
+		return nil, "", fmt.Errorf("actor service can't list revisions: %w", err)
+	}
+
+	return revisions, next, nil
+}
+
+func (s *actorService) GetRevision(ctx context.Context, actorId int, number int) (*domain.Revision, error) {
+	if err := s.checkActor(ctx, actorId); err != nil {
+		return nil, err
+	}
+	if number <= 0 {
+		return nil, domain.ErrRevisionNotExists
+	}
+
+	revision, err := s.repo.GetRevision(ctx, domain.AuditActor, actorId, number)
+	if err != nil {
+		return nil, fmt.Errorf("actor service can't get revision: %w", err)
+	}
+
+	return revision, nil
+}
+
+// RestoreRevision updates the actor to the state of the revision, which is saved as a new revision
+func (s *actorService) RestoreRevision(ctx context.Context, actorId int, number int, version int) (int, error) {
+	revision, err := s.GetRevision(ctx, actorId, number)
+	if err != nil {
+		return 0, err
+	}
+
+	revision.Actor.Version = version
+	if err := s.UpdateActor(ctx, revision.Actor); err != nil {
+		return 0, err
+	}
+
+	return revision.Actor.Version, nil
+}
+
+func (s *actorService) DiffRevisions(ctx context.Context, actorId int, from int, to int) ([]domain.FieldChange, error) {
+	before, err := s.GetRevision(ctx, actorId, from)
+	if err != nil {
+		return nil, err
+	}
+	after, err := s.GetRevision(ctx, actorId, to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := before.Diff(after)
+	if err != nil {
+		return nil, fmt.Errorf("actor service can't diff revisions: %w", err)
+	}
+
+	return changes, nil
+}
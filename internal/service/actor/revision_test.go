@@ -0,0 +1,45 @@
+package actor
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	"vk-backend/internal/domain"
+	"vk-backend/mocks"
+)
+
+func TestActorService_RestoreRevision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockActorRepository(ctrl)
+	service := NewService(repo)
+
+	actor := &domain.Actor{Id: 1, Name: "name", Gender: 2, BirthDate: time.Date(1980, 1, 2, 0, 0, 0, 0, time.UTC)}
+	repo.EXPECT().ActorExists(gomock.Any(), 1).Return(true, nil).Times(2)
+	repo.EXPECT().GetRevision(gomock.Any(), domain.AuditActor, 1, 2).Return(&domain.Revision{Number: 2, Actor: actor}, nil)
+	repo.EXPECT().UpdateActor(gomock.Any(), actor).DoAndReturn(func(_ context.Context, a *domain.Actor) error {
+		assert.Equal(t, 5, a.Version)
+		a.Version++
+		return nil
+	})
+
+	version, err := service.RestoreRevision(context.Background(), 1, 2, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, version)
+}
+
+func TestActorService_ListRevisions_ActorNotExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockActorRepository(ctrl)
+	service := NewService(repo)
+
+	repo.EXPECT().ActorExists(gomock.Any(), 1).Return(false, nil)
+
+	_, _, err := service.ListRevisions(context.Background(), 1, domain.Page{})
+	assert.ErrorIs(t, err, domain.ErrActorNotExists)
+}
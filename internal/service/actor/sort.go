@@ -0,0 +1,41 @@
+package actor
+
+import "vk-backend/internal/domain"
+
+type SortBy = domain.ActorSortField
+
+const (
+	SortByName       = domain.ActorSortByName
+	SortByBirthDate  = domain.ActorSortByBirthDate
+	SortByMovieCount = domain.ActorSortByMovieCount
+	SortById         = domain.ActorSortById
+)
+
+// Sorting is empty by default, then actors are listed in insertion order
+type Sorting = domain.ActorSort
+
+// defaultDesc tells in which direction a field is sorted unless the direction is given explicitly
+var defaultDesc = map[SortBy]bool{
+	SortByName:       false,
+	SortByBirthDate:  false,
+	SortByMovieCount: true,
+	SortById:         false,
+}
+
+// NewSortKey sorts by the field in its default direction: names go in alphabetical order, the eldest actors,
+// the ones with the most movies and the ones added first go first
+func NewSortKey(field SortBy) domain.ActorSortKey {
+	return domain.ActorSortKey{Field: field, Desc: defaultDesc[field]}
+}
+
+func validateSorting(sorting Sorting) error {
+	seen := make(map[SortBy]bool, len(sorting))
+	for _, key := range sorting {
+		if _, ok := defaultDesc[key.Field]; !ok || seen[key.Field] {
+			return domain.ErrInvalidSort
+		}
+		seen[key.Field] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package actor
+
+import (
+	"context"
+	"fmt"
+	"vk-backend/internal/domain"
+)
+
+func (s *actorService) ListDeletedActors(ctx context.Context, page domain.Page) ([]*domain.Actor, string, error) {
+	actors, next, err := s.repo.ListDeletedActors(ctx, page.Normalize())
+	if err != nil {
+		return nil, "", fmt.Errorf("actor service can't list deleted actors: %w", err)
+	}
+
+	return actors, next, nil
+}
+
+func (s *actorService) RestoreActor(ctx context.Context, id int) error {
+	if id <= 0 {
+		return domain.ErrNotInTrash
+	}
+	ok, err := s.repo.RestoreActor(ctx, id)
+	if err != nil {
+		return fmt.Errorf("actor service can't restore actor: %w", err)
+	}
+	if !ok {
+		return domain.ErrNotInTrash
+	}
+
+	return nil
+}
+
+func (s *actorService) PurgeActor(ctx context.Context, id int) error {
+	if id <= 0 {
+		return domain.ErrNotInTrash
+	}
+	ok, err := s.repo.PurgeActor(ctx, id)
+	if err != nil {
+		return fmt.Errorf("actor service can't purge actor: %w", err)
+	}
+	if !ok {
+		return domain.ErrNotInTrash
+	}
+
+	return nil
+}
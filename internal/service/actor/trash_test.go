@@ -0,0 +1,60 @@
+package actor
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	"vk-backend/internal/domain"
+	"vk-backend/mocks"
+)
+
+func TestActorService_ListDeletedActors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockActorRepository(ctrl)
+	service := NewService(repo)
+
+	deletedAt := time.Now()
+	repo.
+		EXPECT().
+		ListDeletedActors(gomock.Any(), domain.Page{Limit: domain.DefaultPageLimit}).
+		Return([]*domain.Actor{{Id: 1, Name: "name", DeletedAt: &deletedAt}}, "next", nil)
+
+	actors, next, err := service.ListDeletedActors(context.Background(), domain.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, "next", next)
+	assert.Equal(t, []*domain.Actor{{Id: 1, Name: "name", DeletedAt: &deletedAt}}, actors)
+}
+
+func TestActorService_RestoreActor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockActorRepository(ctrl)
+	service := NewService(repo)
+
+	repo.EXPECT().RestoreActor(gomock.Any(), 1).Return(true, nil)
+	assert.NoError(t, service.RestoreActor(context.Background(), 1))
+
+	repo.EXPECT().RestoreActor(gomock.Any(), 2).Return(false, nil)
+	assert.ErrorIs(t, service.RestoreActor(context.Background(), 2), domain.ErrNotInTrash)
+
+	assert.ErrorIs(t, service.RestoreActor(context.Background(), 0), domain.ErrNotInTrash)
+}
+
+func TestActorService_PurgeActor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockActorRepository(ctrl)
+	service := NewService(repo)
+
+	repo.EXPECT().PurgeActor(gomock.Any(), 1).Return(true, nil)
+	assert.NoError(t, service.PurgeActor(context.Background(), 1))
+
+	repo.EXPECT().PurgeActor(gomock.Any(), 2).Return(false, nil)
+	assert.ErrorIs(t, service.PurgeActor(context.Background(), 2), domain.ErrNotInTrash)
+}
@@ -0,0 +1,54 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"vk-backend/internal/domain"
+	"vk-backend/internal/repository"
+)
+
+// AuditService reads the log of admin changes, the log itself is written by repositories along with the changes
+type AuditService interface {
+	// ListAuditLog returns a page of changes matching the filter, the latest first
+	ListAuditLog(ctx context.Context, filter domain.AuditFilter, page domain.Page) ([]*domain.AuditEntry, string, error)
+}
+
+type auditService struct {
+	repo repository.AuditRepository
+}
+
+func NewService(repo repository.AuditRepository) AuditService {
+	return &auditService{
+		repo: repo,
+	}
+}
+
+func (s *auditService) ListAuditLog(ctx context.Context, filter domain.AuditFilter, page domain.Page) ([]*domain.AuditEntry, string, error) {
+	if err := validateFilter(filter); err != nil {
+		return nil, "", err
+	}
+
+	entries, next, err := s.repo.ListAuditLog(ctx, filter, page.Normalize())
+	if err != nil {
+		return nil, "", fmt.Errorf("audit service can't list audit log: %w", err)
+	}
+
+	return entries, next, nil
+}
+
+func validateFilter(filter domain.AuditFilter) error {
+	if filter.UserId != nil && *filter.UserId <= 0 {
+		return domain.ErrInvalidAuditFilter
+	}
+	if filter.Entity != nil && !filter.Entity.Valid() {
+		return domain.ErrInvalidAuditFilter
+	}
+	if filter.EntityId != nil && (filter.Entity == nil || *filter.EntityId <= 0) {
+		return domain.ErrInvalidAuditFilter
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return domain.ErrInvalidAuditFilter
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package audit
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	"vk-backend/internal/domain"
+	"vk-backend/mocks"
+)
+
+func TestAuditService_ListAuditLog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockAuditRepository(ctrl)
+	service := NewService(repo)
+
+	userId, movieId := 1, 2
+	entity := domain.AuditMovie
+	filter := domain.AuditFilter{UserId: &userId, Entity: &entity, EntityId: &movieId}
+	expected := []*domain.AuditEntry{{Id: 3, UserId: &userId, Action: domain.AuditDelete, Entity: entity, EntityId: movieId}}
+	repo.
+		EXPECT().
+		ListAuditLog(gomock.Any(), filter, domain.Page{Limit: domain.DefaultPageLimit}).
+		Return(expected, "next", nil)
+
+	entries, next, err := service.ListAuditLog(context.Background(), filter, domain.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, "next", next)
+	assert.Equal(t, expected, entries)
+}
+
+func TestAuditService_ListAuditLog_InvalidFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockAuditRepository(ctrl)
+	service := NewService(repo)
+
+	id, zero := 1, 0
+	unknown := domain.AuditEntity("genre")
+	cast := domain.AuditCast
+	now := time.Now()
+	before := now.Add(-time.Hour)
+
+	for _, filter := range []domain.AuditFilter{
+		{UserId: &zero},
+		{Entity: &unknown},
+		{EntityId: &id},
+		{Entity: &cast, EntityId: &zero},
+		{From: &now, To: &before},
+		{From: &now, To: &now},
+	} {
+		_, _, err := service.ListAuditLog(context.Background(), filter, domain.Page{})
+		assert.ErrorIs(t, err, domain.ErrInvalidAuditFilter)
+	}
+}
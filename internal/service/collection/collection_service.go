@@ -0,0 +1,80 @@
+package collection
+
+import (
+	"context"
+	"fmt"
+	"vk-backend/internal/domain"
+	"vk-backend/internal/repository"
+)
+
+// CollectionService manages watchlists and favorites of users, movies of a collection are listed by movie.MovieService
+type CollectionService interface {
+	AddMovie(ctx context.Context, c domain.UserCollection, movieId int) error
+	RemoveMovie(ctx context.Context, c domain.UserCollection, movieId int) error
+
+	// Contains tells which of the movies are in the collection
+	Contains(ctx context.Context, c domain.UserCollection, movieIds []int) (map[int]bool, error)
+}
+
+type collectionService struct {
+	repo repository.CollectionRepository
+}
+
+func NewService(repo repository.CollectionRepository) CollectionService {
+	return &collectionService{
+		repo: repo,
+	}
+}
+
+// AddMovie adds the movie to the collection, adding it again changes nothing
+func (s *collectionService) AddMovie(ctx context.Context, c domain.UserCollection, movieId int) error {
+	if !c.Collection.Valid() {
+		return domain.ErrCollectionNotExists
+	}
+	if movieId <= 0 {
+		return domain.ErrMovieNotExists
+	}
+	ok, err := s.repo.MovieExists(ctx, movieId)
+	if err != nil {
+		return fmt.Errorf("collection service can't check if movie exists: %w", err)
+	}
+	if !ok {
+		return domain.ErrMovieNotExists
+	}
+
+	err = s.repo.AddToCollection(ctx, c, movieId)
+	if err != nil {
+		return fmt.Errorf("collection service can't add movie: %w", err)
+	}
+
+	return nil
+}
+
+func (s *collectionService) RemoveMovie(ctx context.Context, c domain.UserCollection, movieId int) error {
+	if !c.Collection.Valid() {
+		return domain.ErrCollectionNotExists
+	}
+
+	ok, err := s.repo.RemoveFromCollection(ctx, c, movieId)
+	if err != nil {
+		return fmt.Errorf("collection service can't remove movie: %w", err)
+	}
+	if !ok {
+		return domain.ErrMovieNotInCollection
+	}
+
+	return nil
+}
+
+func (s *collectionService) Contains(ctx context.Context, c domain.UserCollection, movieIds []int) (map[int]bool, error) {
+	if !c.Collection.Valid() {
+		return nil, domain.ErrCollectionNotExists
+	}
+
+	in, err := s.repo.InCollection(ctx, c, movieIds)
+	if err != nil {
+		return nil, fmt.Errorf("collection service can't check movies in collection: %w", err)
+	}
+
+	return in, nil
+}
@@ -0,0 +1,91 @@
+package collection
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"vk-backend/internal/domain"
+	"vk-backend/mocks"
+)
+
+var watchlist = domain.UserCollection{UserId: 1, Collection: domain.Watchlist}
+
+func TestCollectionService_AddMovie(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockCollectionRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		MovieExists(gomock.Any(), 2).
+		Return(true, nil)
+	repo.
+		EXPECT().
+		AddToCollection(gomock.Any(), watchlist, 2).
+		Return(nil)
+
+	err := service.AddMovie(context.Background(), watchlist, 2)
+	assert.NoError(t, err)
+}
+
+func TestCollectionService_AddMovie_NotExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockCollectionRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		MovieExists(gomock.Any(), 2).
+		Return(false, nil)
+
+	err := service.AddMovie(context.Background(), watchlist, 2)
+	assert.ErrorIs(t, err, domain.ErrMovieNotExists)
+
+	err = service.AddMovie(context.Background(), domain.UserCollection{UserId: 1, Collection: "seen"}, 2)
+	assert.ErrorIs(t, err, domain.ErrCollectionNotExists)
+}
+
+func TestCollectionService_RemoveMovie(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockCollectionRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		RemoveFromCollection(gomock.Any(), watchlist, 2).
+		Return(true, nil)
+	repo.
+		EXPECT().
+		RemoveFromCollection(gomock.Any(), watchlist, 3).
+		Return(false, nil)
+
+	err := service.RemoveMovie(context.Background(), watchlist, 2)
+	assert.NoError(t, err)
+
+	err = service.RemoveMovie(context.Background(), watchlist, 3)
+	assert.ErrorIs(t, err, domain.ErrMovieNotInCollection)
+}
+
+func TestCollectionService_Contains(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockCollectionRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		InCollection(gomock.Any(), watchlist, []int{1, 2}).
+		Return(map[int]bool{2: true}, nil)
+
+	in, err := service.Contains(context.Background(), watchlist, []int{1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]bool{2: true}, in)
+}
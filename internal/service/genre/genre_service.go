@@ -0,0 +1,147 @@
+package genre
+
+import (
+	"context"
+	"fmt"
+	"vk-backend/internal/domain"
+	"vk-backend/internal/repository"
+)
+
+type GenreService interface {
+	AddGenre(ctx context.Context, name string) (*domain.Genre, error)
+	GetGenreById(ctx context.Context, id int) (*domain.Genre, error)
+
+	UpdateGenre(ctx context.Context, new *domain.Genre) error
+	DeleteGenre(ctx context.Context, id int) error
+
+	ListGenres(ctx context.Context) ([]*domain.Genre, error)
+}
+
+type genreService struct {
+	repo repository.GenreRepository
+}
+
+func NewService(repo repository.GenreRepository) GenreService {
+	return &genreService{
+		repo: repo,
+	}
+}
+
+func (s *genreService) AddGenre(ctx context.Context, name string) (*domain.Genre, error) {
+	if err := validateGenreName(name); err != nil {
+		return nil, err
+	}
+
+	ok, err := s.repo.GenreNameExists(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("genre service can't check if genre name exists: %w", err)
+	}
+	if ok {
+		return nil, domain.ErrGenreAlreadyExists
+	}
+
+	genre, err := s.repo.AddGenre(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("genre service can't add genre: %w", err)
+	}
+
+	return genre, nil
+}
+
+func (s *genreService) GetGenreById(ctx context.Context, id int) (*domain.Genre, error) {
+	if id <= 0 {
+		return nil, domain.ErrGenreNotExists
+	}
+	ok, err := s.repo.GenreExists(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("genre service can't check if genre exists: %w", err)
+	}
+	if !ok {
+		return nil, domain.ErrGenreNotExists
+	}
+
+	genre, err := s.repo.GetGenreById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("genre service can't get genre by id: %w", err)
+	}
+
+	return genre, nil
+}
+
+func (s *genreService) UpdateGenre(ctx context.Context, new *domain.Genre) error {
+	if new.Id <= 0 {
+		return domain.ErrGenreNotExists
+	}
+	ok, err := s.repo.GenreExists(ctx, new.Id)
+	if err != nil {
+		return fmt.Errorf("genre service can't check if genre exists: %w", err)
+	}
+	if !ok {
+		return domain.ErrGenreNotExists
+	}
+
+	if err := validateGenreName(new.Name); err != nil {
+		return err
+	}
+
+	old, err := s.repo.GetGenreById(ctx, new.Id)
+	if err != nil {
+		return fmt.Errorf("genre service can't get genre by id: %w", err)
+	}
+	if old.Name != new.Name {
+		ok, err = s.repo.GenreNameExists(ctx, new.Name)
+		if err != nil {
+			return fmt.Errorf("genre service can't check if genre name exists: %w", err)
+		}
+		if ok {
+			return domain.ErrGenreAlreadyExists
+		}
+	}
+
+	err = s.repo.UpdateGenre(ctx, new)
+	if err != nil {
+		return fmt.Errorf("genre service can't update genre: %w", err)
+	}
+
+	return nil
+}
+
+func (s *genreService) DeleteGenre(ctx context.Context, id int) error {
+	if id <= 0 {
+		return domain.ErrGenreNotExists
+	}
+	ok, err := s.repo.GenreExists(ctx, id)
+	if err != nil {
+		return fmt.Errorf("genre service can't check if genre exists: %w", err)
+	}
+	if !ok {
+		return domain.ErrGenreNotExists
+	}
+
+	err = s.repo.DeleteGenre(ctx, id)
+	if err != nil {
+		return fmt.Errorf("genre service can't delete genre: %w", err)
+	}
+
+	return nil
+}
+
+func (s *genreService) ListGenres(ctx context.Context) ([]*domain.Genre, error) {
+	genres, err := s.repo.ListGenres(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("genre service can't list genres: %w", err)
+	}
+
+	return genres, nil
+}
+
+func validateGenreName(name string) error {
+	if name == "" {
+		return domain.ErrEmptyName
+	}
+	if len(name) > 50 {
+		return domain.ErrTooLongName
+	}
+
+	return nil
+}
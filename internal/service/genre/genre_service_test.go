@@ -0,0 +1,209 @@
+package genre
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"strings"
+	"testing"
+	"vk-backend/internal/domain"
+	"vk-backend/mocks"
+)
+
+func TestGenreService_AddGenre(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockGenreRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		GenreNameExists(gomock.Any(), "drama").
+		Return(false, nil)
+	repo.
+		EXPECT().
+		AddGenre(gomock.Any(), "drama").
+		Return(&domain.Genre{Id: 1, Name: "drama"}, nil)
+
+	genre, err := service.AddGenre(context.Background(), "drama")
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Genre{Id: 1, Name: "drama"}, genre)
+}
+
+func TestGenreService_AddGenre_InvalidName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockGenreRepository(ctrl)
+	service := NewService(repo)
+
+	genre, err := service.AddGenre(context.Background(), "")
+	assert.ErrorIs(t, err, domain.ErrEmptyName)
+	assert.Nil(t, genre)
+
+	genre, err = service.AddGenre(context.Background(), strings.Repeat("a", 51))
+	assert.ErrorIs(t, err, domain.ErrTooLongName)
+	assert.Nil(t, genre)
+}
+
+func TestGenreService_AddGenre_AlreadyExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockGenreRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		GenreNameExists(gomock.Any(), "drama").
+		Return(true, nil)
+
+	genre, err := service.AddGenre(context.Background(), "drama")
+	assert.ErrorIs(t, err, domain.ErrGenreAlreadyExists)
+	assert.Nil(t, genre)
+}
+
+func TestGenreService_GetGenreById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockGenreRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		GenreExists(gomock.Any(), 1).
+		Return(true, nil)
+	repo.
+		EXPECT().
+		GetGenreById(gomock.Any(), 1).
+		Return(&domain.Genre{Id: 1, Name: "drama"}, nil)
+
+	genre, err := service.GetGenreById(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Genre{Id: 1, Name: "drama"}, genre)
+}
+
+func TestGenreService_GetGenreById_NotExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockGenreRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		GenreExists(gomock.Any(), 1).
+		Return(false, nil)
+
+	genre, err := service.GetGenreById(context.Background(), 1)
+	assert.ErrorIs(t, err, domain.ErrGenreNotExists)
+	assert.Nil(t, genre)
+}
+
+func TestGenreService_UpdateGenre(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockGenreRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		GenreExists(gomock.Any(), 1).
+		Return(true, nil)
+	repo.
+		EXPECT().
+		GetGenreById(gomock.Any(), 1).
+		Return(&domain.Genre{Id: 1, Name: "drama"}, nil)
+	repo.
+		EXPECT().
+		GenreNameExists(gomock.Any(), "comedy").
+		Return(false, nil)
+	repo.
+		EXPECT().
+		UpdateGenre(gomock.Any(), &domain.Genre{Id: 1, Name: "comedy"}).
+		Return(nil)
+
+	err := service.UpdateGenre(context.Background(), &domain.Genre{Id: 1, Name: "comedy"})
+	assert.NoError(t, err)
+}
+
+func TestGenreService_UpdateGenre_NameTaken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockGenreRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		GenreExists(gomock.Any(), 1).
+		Return(true, nil)
+	repo.
+		EXPECT().
+		GetGenreById(gomock.Any(), 1).
+		Return(&domain.Genre{Id: 1, Name: "drama"}, nil)
+	repo.
+		EXPECT().
+		GenreNameExists(gomock.Any(), "comedy").
+		Return(true, nil)
+
+	err := service.UpdateGenre(context.Background(), &domain.Genre{Id: 1, Name: "comedy"})
+	assert.ErrorIs(t, err, domain.ErrGenreAlreadyExists)
+}
+
+func TestGenreService_DeleteGenre(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockGenreRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		GenreExists(gomock.Any(), 1).
+		Return(true, nil)
+	repo.
+		EXPECT().
+		DeleteGenre(gomock.Any(), 1).
+		Return(nil)
+
+	err := service.DeleteGenre(context.Background(), 1)
+	assert.NoError(t, err)
+}
+
+func TestGenreService_DeleteGenre_NotExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockGenreRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		GenreExists(gomock.Any(), 1).
+		Return(false, nil)
+
+	err := service.DeleteGenre(context.Background(), 1)
+	assert.ErrorIs(t, err, domain.ErrGenreNotExists)
+}
+
+func TestGenreService_ListGenres(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockGenreRepository(ctrl)
+	service := NewService(repo)
+
+	expected := []*domain.Genre{{Id: 2, Name: "comedy"}, {Id: 1, Name: "drama"}}
+	repo.
+		EXPECT().
+		ListGenres(gomock.Any()).
+		Return(expected, nil)
+
+	genres, err := service.ListGenres(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, expected, genres)
+}
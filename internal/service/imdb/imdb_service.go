@@ -0,0 +1,274 @@
+package imdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+	"vk-backend/internal/domain"
+	"vk-backend/internal/repository"
+	"vk-backend/internal/service/actor"
+	"vk-backend/internal/service/movie"
+)
+
+// Sources are the dataset files in the order they are imported, credits need both movies and actors
+const (
+	SourceTitles     = "title.basics"
+	SourceNames      = "name.basics"
+	SourcePrincipals = "title.principals"
+
+	batchSize = 5000
+
+	maxCharacterLength = 150
+)
+
+// Files are the dataset files to import, a nil file is skipped
+type Files struct {
+	Titles     io.Reader
+	Names      io.Reader
+	Principals io.Reader
+}
+
+// Progress is reported after every batch. Lines counts all the lines of the source read so far,
+// including the ones imported by previous runs, Written and Skipped only count lines of this run.
+type Progress struct {
+	Source  string
+	Lines   int64
+	Written int
+	Skipped int
+	Done    bool
+}
+
+// ImdbService imports IMDb datasets (https://developer.imdb.com/non-commercial-datasets/). Records are matched
+// by tconst and nconst, so importing the same files again only updates what has changed. Each batch is saved
+// with the number of imported lines, an interrupted import continues from the last saved batch.
+type ImdbService interface {
+	Import(ctx context.Context, files Files, progress func(Progress)) error
+	// Reset forgets the imported lines, so the next import reads the files from the beginning
+	Reset(ctx context.Context) error
+}
+
+type imdbService struct {
+	repo repository.DatasetRepository
+}
+
+func NewService(repo repository.DatasetRepository) ImdbService {
+	return &imdbService{
+		repo: repo,
+	}
+}
+
+func (s *imdbService) Import(ctx context.Context, files Files, progress func(Progress)) error {
+	if progress == nil {
+		progress = func(Progress) {}
+	}
+	if files.Titles != nil {
+		err := importSource(ctx, s, SourceTitles, files.Titles, titleColumns, parseTitle, s.repo.UpsertExternalMovies, progress)
+		if err != nil {
+			return err
+		}
+	}
+	if files.Names != nil {
+		err := importSource(ctx, s, SourceNames, files.Names, nameColumns, parseName, s.repo.UpsertExternalActors, progress)
+		if err != nil {
+			return err
+		}
+	}
+	if files.Principals != nil {
+		err := importSource(ctx, s, SourcePrincipals, files.Principals, principalColumns, parsePrincipal, s.repo.UpsertExternalCredits, progress)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *imdbService) Reset(ctx context.Context) error {
+	if err := s.repo.ResetCheckpoints(ctx, []string{SourceTitles, SourceNames, SourcePrincipals}); err != nil {
+		return fmt.Errorf("imdb service can't reset checkpoints: %w", err)
+	}
+
+	return nil
+}
+
+type upsertFunc[T any] func(ctx context.Context, batch []T, cp domain.Checkpoint) (int, error)
+
+// importSource skips the lines imported before and writes the rest in batches, parse tells whether the line is imported
+func importSource[T any](
+	ctx context.Context,
+	s *imdbService,
+	source string,
+	r io.Reader,
+	columns []string,
+	parse func(r *tsvReader) (T, bool),
+	upsert upsertFunc[T],
+	progress func(Progress),
+) error {
+	done, err := s.repo.GetCheckpoint(ctx, source)
+	if err != nil {
+		return fmt.Errorf("imdb service can't get checkpoint: %w", err)
+	}
+	reader, err := newTSVReader(r, columns...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", source, err)
+	}
+
+	p := Progress{Source: source}
+	batch := make([]T, 0, batchSize)
+	flush := func() error {
+		if reader.line <= done {
+			return nil
+		}
+		written, err := upsert(ctx, batch, domain.Checkpoint{Source: source, Line: reader.line})
+		if err != nil {
+			return fmt.Errorf("imdb service can't import %s: %w", source, err)
+		}
+		p.Written += written
+		p.Lines = reader.line
+		progress(p)
+		batch = batch[:0]
+		return nil
+	}
+
+	for reader.next() {
+		if reader.line <= done {
+			continue
+		}
+		item, ok := parse(reader)
+		if !ok {
+			p.Skipped++
+			continue
+		}
+		batch = append(batch, item)
+		if len(batch) == batchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := reader.err(); err != nil {
+		return fmt.Errorf("%s: %w", source, err)
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	p.Lines, p.Done = reader.line, true
+	progress(p)
+	return nil
+}
+
+var titleColumns = []string{"tconst", "titleType", "primaryTitle", "isAdult", "startYear", "runtimeMinutes", "genres"}
+
+// parseTitle takes feature films with a release year, the datasets have no plot, so the description sums up
+// the genres, the year and the runtime
+func parseTitle(r *tsvReader) (*domain.ExternalMovie, bool) {
+	if r.get("titleType") != "movie" || r.get("isAdult") == "1" {
+		return nil, false
+	}
+	year, err := strconv.Atoi(r.get("startYear"))
+	if err != nil {
+		return nil, false
+	}
+
+	details := make([]string, 0, 3)
+	if genres := r.get("genres"); genres != "" {
+		details = append(details, strings.ReplaceAll(genres, ",", ", "))
+	}
+	details = append(details, strconv.Itoa(year))
+	if runtime := r.get("runtimeMinutes"); runtime != "" {
+		details = append(details, runtime+" min")
+	}
+
+	m := &domain.ExternalMovie{
+		ExternalId: r.get("tconst"),
+		Movie: &domain.Movie{
+			Title:       r.get("primaryTitle"),
+			Description: strings.Join(details, " · "),
+			ReleaseDate: time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	if movie.ValidateMovieData(m.Title, m.Description, m.ReleaseDate, m.Rating) != nil {
+		return nil, false
+	}
+
+	return m, true
+}
+
+var nameColumns = []string{"nconst", "primaryName", "birthYear", "primaryProfession"}
+
+// parseName takes actors and actresses with a birth year, the profession tells the gender
+func parseName(r *tsvReader) (*domain.ExternalActor, bool) {
+	year, err := strconv.Atoi(r.get("birthYear"))
+	if err != nil {
+		return nil, false
+	}
+	gender := -1
+	for _, profession := range strings.Split(r.get("primaryProfession"), ",") {
+		switch profession {
+		case "actor":
+			gender = 1
+		case "actress":
+			gender = 2
+		}
+	}
+	if gender < 0 {
+		return nil, false
+	}
+
+	a := &domain.ExternalActor{
+		ExternalId: r.get("nconst"),
+		Actor: &domain.Actor{
+			Name:      r.get("primaryName"),
+			Gender:    gender,
+			BirthDate: time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	if actor.ValidateActorData(a.Name, a.BirthDate, a.Gender) != nil {
+		return nil, false
+	}
+
+	return a, true
+}
+
+var principalColumns = []string{"tconst", "ordering", "nconst", "category", "characters"}
+
+// parsePrincipal takes acting credits, the position among the principals is the billing
+func parsePrincipal(r *tsvReader) (*domain.ExternalCredit, bool) {
+	if category := r.get("category"); category != "actor" && category != "actress" {
+		return nil, false
+	}
+	billing, err := strconv.Atoi(r.get("ordering"))
+	if err != nil || billing <= 0 {
+		return nil, false
+	}
+
+	// characters are a JSON array of names
+	var characters []string
+	if c := r.get("characters"); c != "" {
+		_ = json.Unmarshal([]byte(c), &characters)
+	}
+
+	return &domain.ExternalCredit{
+		MovieId:   r.get("tconst"),
+		ActorId:   r.get("nconst"),
+		Character: truncate(strings.Join(characters, " / "), maxCharacterLength),
+		Billing:   billing,
+	}, true
+}
+
+// truncate cuts the string to at most n bytes without breaking a character
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
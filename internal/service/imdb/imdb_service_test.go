@@ -0,0 +1,117 @@
+package imdb
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"strings"
+	"testing"
+	"time"
+	"vk-backend/internal/domain"
+	"vk-backend/mocks"
+)
+
+const titles = "tconst\ttitleType\tprimaryTitle\toriginalTitle\tisAdult\tstartYear\tendYear\truntimeMinutes\tgenres\n" +
+	"tt0109830\tmovie\tForrest Gump\tForrest Gump\t0\t1994\t\\N\t142\tDrama,Romance\n" +
+	"tt0108778\ttvSeries\tFriends\tFriends\t0\t1994\t2004\t22\tComedy,Romance\n" +
+	"tt0000001\tmovie\tUnknown\tUnknown\t0\t\\N\t\\N\t\\N\t\\N\n" +
+	"tt0162222\tmovie\tCast Away\tCast Away\t0\t2000\t\\N\t\\N\t\\N\n"
+
+const names = "nconst\tprimaryName\tbirthYear\tdeathYear\tprimaryProfession\tknownForTitles\n" +
+	"nm0000158\tTom Hanks\t1956\t\\N\tproducer,actor,soundtrack\ttt0109830\n" +
+	"nm0000705\tRobin Wright\t1966\t\\N\tactress,producer\ttt0109830\n" +
+	"nm0000709\tRobert Zemeckis\t1952\t\\N\tproducer,director\ttt0109830\n" +
+	"nm0000001\tNo Birth\t\\N\t\\N\tactor\t\\N\n"
+
+const principals = "tconst\tordering\tnconst\tcategory\tjob\tcharacters\n" +
+	"tt0109830\t1\tnm0000158\tactor\t\\N\t[\"Forrest Gump\"]\n" +
+	"tt0109830\t2\tnm0000705\tactress\t\\N\t[\"Jenny Curran\"]\n" +
+	"tt0109830\t5\tnm0000709\tdirector\t\\N\t\\N\n"
+
+func TestImdbService_Import(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockDatasetRepository(ctrl)
+	service := NewService(repo)
+
+	repo.EXPECT().GetCheckpoint(gomock.Any(), gomock.Any()).Return(int64(0), nil).Times(3)
+	repo.
+		EXPECT().
+		UpsertExternalMovies(gomock.Any(), gomock.Len(2), domain.Checkpoint{Source: SourceTitles, Line: 4}).
+		DoAndReturn(func(_ context.Context, movies []*domain.ExternalMovie, _ domain.Checkpoint) (int, error) {
+			assert.Equal(t, "tt0109830", movies[0].ExternalId)
+			assert.Equal(t, "Forrest Gump", movies[0].Title)
+			assert.Equal(t, "Drama, Romance · 1994 · 142 min", movies[0].Description)
+			assert.Equal(t, time.Date(1994, 1, 1, 0, 0, 0, 0, time.UTC), movies[0].ReleaseDate)
+			assert.Equal(t, "2000", movies[1].Description)
+			return 2, nil
+		})
+	repo.
+		EXPECT().
+		UpsertExternalActors(gomock.Any(), gomock.Len(2), domain.Checkpoint{Source: SourceNames, Line: 4}).
+		DoAndReturn(func(_ context.Context, actors []*domain.ExternalActor, _ domain.Checkpoint) (int, error) {
+			assert.Equal(t, "nm0000158", actors[0].ExternalId)
+			assert.Equal(t, 1, actors[0].Gender)
+			assert.Equal(t, 2, actors[1].Gender)
+			return 2, nil
+		})
+	repo.
+		EXPECT().
+		UpsertExternalCredits(gomock.Any(), gomock.Len(2), domain.Checkpoint{Source: SourcePrincipals, Line: 3}).
+		DoAndReturn(func(_ context.Context, credits []*domain.ExternalCredit, _ domain.Checkpoint) (int, error) {
+			assert.Equal(t, domain.ExternalCredit{MovieId: "tt0109830", ActorId: "nm0000705", Character: "Jenny Curran", Billing: 2}, *credits[1])
+			return 2, nil
+		})
+
+	var reports []Progress
+	err := service.Import(context.Background(), Files{
+		Titles:     strings.NewReader(titles),
+		Names:      strings.NewReader(names),
+		Principals: strings.NewReader(principals),
+	}, func(p Progress) { reports = append(reports, p) })
+	assert.NoError(t, err)
+	assert.Contains(t, reports, Progress{Source: SourceTitles, Lines: 4, Written: 2, Skipped: 2, Done: true})
+	assert.Contains(t, reports, Progress{Source: SourceNames, Lines: 4, Written: 2, Skipped: 2, Done: true})
+	assert.Contains(t, reports, Progress{Source: SourcePrincipals, Lines: 3, Written: 2, Skipped: 1, Done: true})
+}
+
+func TestImdbService_Import_Resume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockDatasetRepository(ctrl)
+	service := NewService(repo)
+
+	repo.EXPECT().GetCheckpoint(gomock.Any(), SourceTitles).Return(int64(2), nil)
+	repo.
+		EXPECT().
+		UpsertExternalMovies(gomock.Any(), gomock.Len(1), domain.Checkpoint{Source: SourceTitles, Line: 4}).
+		DoAndReturn(func(_ context.Context, movies []*domain.ExternalMovie, _ domain.Checkpoint) (int, error) {
+			assert.Equal(t, "tt0162222", movies[0].ExternalId)
+			return 1, nil
+		})
+	repo.EXPECT().GetCheckpoint(gomock.Any(), SourceNames).Return(int64(4), nil)
+
+	err := service.Import(context.Background(), Files{Titles: strings.NewReader(titles), Names: strings.NewReader(names)}, nil)
+	assert.NoError(t, err)
+}
+
+func TestImdbService_Import_InvalidFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockDatasetRepository(ctrl)
+	service := NewService(repo)
+
+	repo.EXPECT().GetCheckpoint(gomock.Any(), SourceTitles).Return(int64(0), nil)
+
+	err := service.Import(context.Background(), Files{Titles: strings.NewReader(names)}, nil)
+	assert.ErrorIs(t, err, domain.ErrInvalidImportFile)
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "abc", truncate("abc", 5))
+	assert.Equal(t, "ab", truncate("abc", 2))
+	assert.Equal(t, "д", truncate("дом", 3))
+}
@@ -0,0 +1,71 @@
+package imdb
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"vk-backend/internal/domain"
+)
+
+// null is how the datasets mark missing values
+const null = `\N`
+
+const maxLineSize = 1 << 20
+
+// tsvReader reads tab separated lines without quoting, columns are looked up by the names of the header
+type tsvReader struct {
+	scanner *bufio.Scanner
+	columns map[string]int
+	fields  []string
+	line    int64 // data lines read, the header isn't counted
+}
+
+func newTSVReader(r io.Reader, required ...string) (*tsvReader, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("%w: %w", domain.ErrInvalidImportFile, err)
+		}
+		return nil, fmt.Errorf("%w: header is missing", domain.ErrInvalidImportFile)
+	}
+
+	columns := map[string]int{}
+	for i, name := range strings.Split(scanner.Text(), "\t") {
+		columns[name] = i
+	}
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%w: %s column is missing", domain.ErrInvalidImportFile, name)
+		}
+	}
+
+	return &tsvReader{scanner: scanner, columns: columns}, nil
+}
+
+// next reads the following line, it returns false at the end of the file or on an error
+func (r *tsvReader) next() bool {
+	if !r.scanner.Scan() {
+		return false
+	}
+	r.line++
+	r.fields = strings.Split(r.scanner.Text(), "\t")
+	return true
+}
+
+func (r *tsvReader) err() error {
+	if err := r.scanner.Err(); err != nil {
+		return fmt.Errorf("%w: line %d: %w", domain.ErrInvalidImportFile, r.line+2, err)
+	}
+	return nil
+}
+
+// get returns the value of the column, missing values are empty
+func (r *tsvReader) get(column string) string {
+	i := r.columns[column]
+	if i >= len(r.fields) || r.fields[i] == null {
+		return ""
+	}
+	return r.fields[i]
+}
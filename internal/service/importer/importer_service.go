@@ -0,0 +1,168 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+	"vk-backend/internal/domain"
+	"vk-backend/internal/repository"
+	"vk-backend/internal/service/actor"
+	"vk-backend/internal/service/movie"
+)
+
+// ImportService loads actors and movies in bulk, rows are checked by the same rules as records added through the API
+type ImportService interface {
+	// Import writes the valid rows of the file and reports the rest, a dry run only validates them.
+	// Movies may refer to actors of the same file by name and birth date.
+	Import(ctx context.Context, format Format, r io.Reader, dryRun bool) (*domain.ImportReport, error)
+}
+
+type importService struct {
+	repo repository.ImportRepository
+}
+
+func NewService(repo repository.ImportRepository) ImportService {
+	return &importService{
+		repo: repo,
+	}
+}
+
+func (s *importService) Import(ctx context.Context, format Format, r io.Reader, dryRun bool) (*domain.ImportReport, error) {
+	rows, err := parse(format, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var actors []*domain.Actor
+	imported := map[string][]*domain.Actor{}
+	for _, row := range rows {
+		if row.err == nil {
+			row.err = validateRow(row)
+		}
+		if row.err == nil && row.actor != nil {
+			actors = append(actors, row.actor)
+			key := actorKey(row.actor.Name, row.actor.BirthDate)
+			imported[key] = append(imported[key], row.actor)
+		}
+	}
+	if err := s.resolveCasts(ctx, rows, imported); err != nil {
+		return nil, err
+	}
+
+	report := &domain.ImportReport{DryRun: dryRun, Actors: len(actors)}
+	var movies []*domain.Movie
+	for _, row := range rows {
+		switch {
+		case row.err != nil:
+			report.Errors = append(report.Errors, domain.ImportError{Line: row.line, Err: row.err})
+		case row.movie != nil:
+			movies = append(movies, row.movie)
+		}
+	}
+	report.Movies = len(movies)
+	if dryRun || len(actors)+len(movies) == 0 {
+		return report, nil
+	}
+
+	if err := s.repo.ImportCatalog(ctx, actors, movies); err != nil {
+		return nil, fmt.Errorf("importer service can't import catalog: %w", err)
+	}
+
+	return report, nil
+}
+
+func validateRow(row *row) error {
+	if a := row.actor; a != nil {
+		return actor.ValidateActorData(a.Name, a.BirthDate, a.Gender)
+	}
+	m := row.movie
+
+	return movie.ValidateMovieData(m.Title, m.Description, m.ReleaseDate, m.Rating)
+}
+
+// resolveCasts finds the actors movies refer to, among existing actors and the ones imported by the file.
+// Actors are billed in the order of the cast.
+func (s *importService) resolveCasts(ctx context.Context, rows []*row, imported map[string][]*domain.Actor) error {
+	var ids []int
+	var named []*domain.Actor
+	for _, row := range rows {
+		if row.err != nil || row.movie == nil {
+			continue
+		}
+		for _, ref := range row.cast {
+			if ref.Id > 0 {
+				ids = append(ids, ref.Id)
+			} else {
+				named = append(named, &domain.Actor{Name: ref.Name, BirthDate: ref.BirthDate})
+			}
+		}
+	}
+
+	byId := map[int]*domain.Actor{}
+	if len(ids) > 0 {
+		var err error
+		if byId, err = s.repo.GetActorsByIds(ctx, ids); err != nil {
+			return fmt.Errorf("importer service can't get actors by ids: %w", err)
+		}
+	}
+	existing := map[string][]*domain.Actor{}
+	if len(named) > 0 {
+		found, err := s.repo.FindActorsByNameAndBirthDate(ctx, named)
+		if err != nil {
+			return fmt.Errorf("importer service can't find actors: %w", err)
+		}
+		for _, a := range found {
+			key := actorKey(a.Name, a.BirthDate)
+			existing[key] = append(existing[key], a)
+		}
+	}
+
+	for _, row := range rows {
+		if row.err != nil || row.movie == nil {
+			continue
+		}
+		row.movie.Actors, row.err = buildCast(row.cast, byId, existing, imported)
+	}
+
+	return nil
+}
+
+func buildCast(refs []actorRef, byId map[int]*domain.Actor, existing, imported map[string][]*domain.Actor) ([]*domain.CastMember, error) {
+	cast := make([]*domain.CastMember, 0, len(refs))
+	seen := make(map[*domain.Actor]bool, len(refs))
+	seenIds := make(map[int]bool, len(refs))
+	for i, ref := range refs {
+		var a *domain.Actor
+		if ref.Id != 0 {
+			a = byId[ref.Id]
+		} else {
+			key := actorKey(ref.Name, ref.BirthDate)
+			switch {
+			case len(existing[key])+len(imported[key]) > 1:
+				return nil, fmt.Errorf("%w: %s", domain.ErrAmbiguousActor, ref)
+			case len(existing[key]) == 1:
+				a = existing[key][0]
+			case len(imported[key]) == 1:
+				a = imported[key][0]
+			}
+		}
+		if a == nil {
+			return nil, fmt.Errorf("%w: %s", domain.ErrActorNotExists, ref)
+		}
+
+		// actors of the file get their ids only when imported
+		if seen[a] || (a.Id != 0 && seenIds[a.Id]) {
+			return nil, fmt.Errorf("%w: %s", domain.ErrActorAlreadyInMovie, ref)
+		}
+		seen[a], seenIds[a.Id] = true, true
+
+		cast = append(cast, &domain.CastMember{Actor: a, Billing: i + 1})
+	}
+
+	return cast, nil
+}
+
+func actorKey(name string, birthDate time.Time) string {
+	return name + "|" + birthDate.Format(time.DateOnly)
+}
@@ -0,0 +1,143 @@
+package importer
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"strings"
+	"testing"
+	"time"
+	"vk-backend/internal/domain"
+	"vk-backend/mocks"
+)
+
+func TestImportService_Import_CSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockImportRepository(ctrl)
+	service := NewService(repo)
+
+	file := `type,name,gender,birth_date,title,description,release_date,rating,cast
+actor,Tom Hanks,male,1956-07-09,,,,,
+movie,,,,Forrest Gump,Life is like a box of chocolates,1994-07-06,8.8,Tom Hanks|1956-07-09; 7
+movie,,,,Cast Away,Stranded on an island,2000-12-22,7.8,Tom Hanks|1956-07-09;Wilson|2000-01-01
+actor,,female,1970-01-01,,,,,
+`
+	existing := &domain.Actor{Id: 7, Name: "Robin Wright"}
+	repo.
+		EXPECT().
+		GetActorsByIds(gomock.Any(), []int{7}).
+		Return(map[int]*domain.Actor{7: existing}, nil)
+	repo.
+		EXPECT().
+		FindActorsByNameAndBirthDate(gomock.Any(), gomock.Len(3)).
+		Return(nil, nil)
+	repo.
+		EXPECT().
+		ImportCatalog(gomock.Any(), gomock.Len(1), gomock.Len(1)).
+		DoAndReturn(func(_ context.Context, actors []*domain.Actor, movies []*domain.Movie) error {
+			assert.Equal(t, "Tom Hanks", actors[0].Name)
+			assert.Equal(t, time.Date(1956, 7, 9, 0, 0, 0, 0, time.UTC), actors[0].BirthDate)
+			assert.Equal(t, "Forrest Gump", movies[0].Title)
+			assert.Equal(t, 8.8, movies[0].Rating)
+			assert.Len(t, movies[0].Actors, 2)
+			assert.Same(t, actors[0], movies[0].Actors[0].Actor)
+			assert.Equal(t, 1, movies[0].Actors[0].Billing)
+			assert.Same(t, existing, movies[0].Actors[1].Actor)
+			assert.Equal(t, 2, movies[0].Actors[1].Billing)
+			return nil
+		})
+
+	report, err := service.Import(context.Background(), FormatCSV, strings.NewReader(file), false)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Actors)
+	assert.Equal(t, 1, report.Movies)
+	assert.Len(t, report.Errors, 2)
+	assert.Equal(t, 4, report.Errors[0].Line)
+	assert.ErrorIs(t, report.Errors[0].Err, domain.ErrActorNotExists)
+	assert.Equal(t, 5, report.Errors[1].Line)
+	assert.ErrorIs(t, report.Errors[1].Err, domain.ErrEmptyName)
+}
+
+func TestImportService_Import_NDJSON_DryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockImportRepository(ctrl)
+	service := NewService(repo)
+
+	file := `{"type": "actor", "name": "Keanu Reeves", "gender": "male", "birth_date": "1964-09-02"}
+
+{"type": "movie", "title": "The Matrix", "description": "Red pill", "release_date": "1999-03-31", "rating": 8.7, "cast": [{"name": "Keanu Reeves", "birth_date": "1964-09-02"}, 3]}
+{"type": "movie", "title": "Speed", "description": "Bus", "release_date": "1994-06-10", "rating": 11}
+{"type": "series", "title": "Friends"}
+{"type": "movie", "title": "John Wick", "description": "Dog", "release_date": "2014-10-24", "cast": [3, 3]}
+{"type": "actor",
+`
+	repo.
+		EXPECT().
+		GetActorsByIds(gomock.Any(), []int{3, 3, 3}).
+		Return(map[int]*domain.Actor{3: {Id: 3}}, nil)
+	repo.
+		EXPECT().
+		FindActorsByNameAndBirthDate(gomock.Any(), gomock.Len(1)).
+		Return(nil, nil)
+
+	report, err := service.Import(context.Background(), FormatNDJSON, strings.NewReader(file), true)
+	assert.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 1, report.Actors)
+	assert.Equal(t, 1, report.Movies)
+	assert.Len(t, report.Errors, 4)
+	assert.Equal(t, 4, report.Errors[0].Line)
+	assert.ErrorIs(t, report.Errors[0].Err, domain.ErrInvalidRating)
+	assert.Equal(t, 5, report.Errors[1].Line)
+	assert.ErrorIs(t, report.Errors[1].Err, domain.ErrInvalidImportRow)
+	assert.Equal(t, 6, report.Errors[2].Line)
+	assert.ErrorIs(t, report.Errors[2].Err, domain.ErrActorAlreadyInMovie)
+	assert.Equal(t, 7, report.Errors[3].Line)
+	assert.ErrorIs(t, report.Errors[3].Err, domain.ErrInvalidImportRow)
+}
+
+func TestImportService_Import_Ambiguous(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockImportRepository(ctrl)
+	service := NewService(repo)
+
+	file := "type,title,description,release_date,cast\nmovie,Heat,Heist,1995-12-15,Al Pacino|1940-04-25\n"
+	birthDate := time.Date(1940, 4, 25, 0, 0, 0, 0, time.UTC)
+	repo.
+		EXPECT().
+		FindActorsByNameAndBirthDate(gomock.Any(), gomock.Len(1)).
+		Return([]*domain.Actor{{Id: 1, Name: "Al Pacino", BirthDate: birthDate}, {Id: 2, Name: "Al Pacino", BirthDate: birthDate}}, nil)
+
+	report, err := service.Import(context.Background(), FormatCSV, strings.NewReader(file), false)
+	assert.NoError(t, err)
+	assert.Zero(t, report.Movies)
+	assert.Len(t, report.Errors, 1)
+	assert.ErrorIs(t, report.Errors[0].Err, domain.ErrAmbiguousActor)
+}
+
+func TestImportService_Import_InvalidFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockImportRepository(ctrl)
+	service := NewService(repo)
+
+	_, err := service.Import(context.Background(), FormatCSV, strings.NewReader("name,title\n"), false)
+	assert.ErrorIs(t, err, domain.ErrInvalidImportFile)
+
+	_, err = service.Import(context.Background(), FormatCSV, strings.NewReader("type,year\n"), false)
+	assert.ErrorIs(t, err, domain.ErrInvalidImportFile)
+
+	_, err = service.Import(context.Background(), "xml", strings.NewReader("<movies/>"), false)
+	assert.ErrorIs(t, err, domain.ErrInvalidImportFormat)
+
+	report, err := service.Import(context.Background(), FormatCSV, strings.NewReader(""), false)
+	assert.NoError(t, err)
+	assert.Empty(t, report.Errors)
+}
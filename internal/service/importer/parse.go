@@ -0,0 +1,298 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"vk-backend/internal/domain"
+)
+
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+
+	MaxRows = 10000
+
+	maxLineSize = 1 << 20
+)
+
+// actorRef points to a cast member either by id or by name and birth date
+type actorRef struct {
+	Id        int
+	Name      string
+	BirthDate time.Time
+}
+
+// UnmarshalJSON accepts a plain actor id as well as an object
+func (r *actorRef) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &r.Id); err == nil {
+		return nil
+	}
+	var ref struct {
+		Id        int    `json:"id"`
+		Name      string `json:"name"`
+		BirthDate string `json:"birth_date"`
+	}
+	if err := json.Unmarshal(data, &ref); err != nil {
+		return err
+	}
+	birthDate, err := parseDate("cast birth_date", ref.BirthDate)
+	if err != nil {
+		return err
+	}
+	*r = actorRef{Id: ref.Id, Name: ref.Name, BirthDate: birthDate}
+	return nil
+}
+
+func (r actorRef) String() string {
+	if r.Id > 0 {
+		return strconv.Itoa(r.Id)
+	}
+	return r.Name + "|" + r.BirthDate.Format(time.DateOnly)
+}
+
+// row is a line of the file, err tells why it can't be imported
+type row struct {
+	line  int
+	actor *domain.Actor
+	movie *domain.Movie
+	cast  []actorRef
+	err   error
+}
+
+// record is a line of either format, type tells whether it's an actor or a movie
+type record struct {
+	Type        string      `json:"type"`
+	Name        string      `json:"name"`
+	Gender      string      `json:"gender"`
+	BirthDate   string      `json:"birth_date"`
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	ReleaseDate string      `json:"release_date"`
+	Rating      json.Number `json:"rating"`
+	Cast        []actorRef  `json:"cast"`
+}
+
+func (r *record) toRow(line int) *row {
+	res := &row{line: line}
+	switch r.Type {
+	case "actor":
+		gender, ok := parseGender(r.Gender)
+		if !ok {
+			res.err = domain.ErrInvalidGender
+			return res
+		}
+		birthDate, err := parseDate("birth_date", r.BirthDate)
+		if err != nil {
+			res.err = err
+			return res
+		}
+		res.actor = &domain.Actor{Name: r.Name, Gender: gender, BirthDate: birthDate}
+	case "movie":
+		releaseDate, err := parseDate("release_date", r.ReleaseDate)
+		if err != nil {
+			res.err = err
+			return res
+		}
+		var rating float64
+		if r.Rating != "" {
+			if rating, err = r.Rating.Float64(); err != nil {
+				res.err = domain.ErrInvalidRating
+				return res
+			}
+		}
+		res.movie = &domain.Movie{Title: r.Title, Description: r.Description, ReleaseDate: releaseDate, Rating: rating}
+		res.cast = r.Cast
+	default:
+		res.err = fmt.Errorf("%w: type must be actor or movie", domain.ErrInvalidImportRow)
+	}
+
+	return res
+}
+
+func parse(format Format, r io.Reader) ([]*row, error) {
+	switch format {
+	case FormatCSV:
+		return parseCSV(r)
+	case FormatNDJSON:
+		return parseNDJSON(r)
+	}
+
+	return nil, domain.ErrInvalidImportFormat
+}
+
+var csvColumns = map[string]bool{
+	"type": true, "name": true, "gender": true, "birth_date": true,
+	"title": true, "description": true, "release_date": true, "rating": true, "cast": true,
+}
+
+// parseCSV reads a file with a header naming the columns in any order, only the type column is required.
+// Cast is a list separated by semicolons, an actor is given by id or as name|birth_date.
+func parseCSV(r io.Reader) ([]*row, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInvalidImportFile, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := columns[name]; ok || !csvColumns[name] {
+			return nil, fmt.Errorf("%w: unexpected column %q", domain.ErrInvalidImportFile, name)
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["type"]; !ok {
+		return nil, fmt.Errorf("%w: type column is missing", domain.ErrInvalidImportFile)
+	}
+
+	var rows []*row
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if len(rows) == MaxRows {
+			return nil, domain.ErrTooManyImportRows
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rows = append(rows, &row{line: parseErr.StartLine, err: fmt.Errorf("%w: %w", domain.ErrInvalidImportRow, parseErr.Err)})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", domain.ErrInvalidImportFile, err)
+		}
+
+		get := func(column string) string {
+			if i, ok := columns[column]; ok {
+				return strings.TrimSpace(fields[i])
+			}
+			return ""
+		}
+		line, _ := reader.FieldPos(0)
+		cast, err := parseCastList(get("cast"))
+		if err != nil {
+			rows = append(rows, &row{line: line, err: err})
+			continue
+		}
+		rec := &record{
+			Type:        get("type"),
+			Name:        get("name"),
+			Gender:      get("gender"),
+			BirthDate:   get("birth_date"),
+			Title:       get("title"),
+			Description: get("description"),
+			ReleaseDate: get("release_date"),
+			Rating:      json.Number(get("rating")),
+			Cast:        cast,
+		}
+		rows = append(rows, rec.toRow(line))
+	}
+
+	return rows, nil
+}
+
+func parseCastList(s string) ([]actorRef, error) {
+	var cast []actorRef
+	for _, entry := range strings.Split(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if id, err := strconv.Atoi(entry); err == nil {
+			cast = append(cast, actorRef{Id: id})
+			continue
+		}
+		name, date, ok := strings.Cut(entry, "|")
+		if !ok {
+			return nil, fmt.Errorf("%w: cast member %q must be an id or name|birth_date", domain.ErrInvalidImportRow, entry)
+		}
+		birthDate, err := parseDate("cast birth_date", strings.TrimSpace(date))
+		if err != nil {
+			return nil, err
+		}
+		cast = append(cast, actorRef{Name: strings.TrimSpace(name), BirthDate: birthDate})
+	}
+
+	return cast, nil
+}
+
+// parseNDJSON reads a JSON object per line, blank lines are skipped
+func parseNDJSON(r io.Reader) ([]*row, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	var rows []*row
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		if len(rows) == MaxRows {
+			return nil, domain.ErrTooManyImportRows
+		}
+
+		rec := &record{}
+		decoder := json.NewDecoder(bytes.NewReader(text))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(rec); err != nil {
+			if !errors.Is(err, domain.ErrInvalidImportRow) {
+				err = fmt.Errorf("%w: %w", domain.ErrInvalidImportRow, err)
+			}
+			rows = append(rows, &row{line: line, err: err})
+			continue
+		}
+		rows = append(rows, rec.toRow(line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInvalidImportFile, err)
+	}
+
+	return rows, nil
+}
+
+// parseDate accepts dates as well as timestamps the API returns, empty date is left zero for validation
+func parseDate(field string, s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if date, err := time.Parse(time.DateOnly, s); err == nil {
+		return date, nil
+	}
+	date, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s %q is not a date", domain.ErrInvalidImportRow, field, s)
+	}
+
+	return date, nil
+}
+
+// parseGender takes the names the API uses for ISO/IEC 5218 codes
+func parseGender(g string) (int, bool) {
+	switch g {
+	case "unknown":
+		return 0, true
+	case "male":
+		return 1, true
+	case "female":
+		return 2, true
+	case "not applicable":
+		return 9, true
+	}
+
+	return 0, false
+}
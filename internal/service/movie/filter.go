@@ -1,64 +1,145 @@
 package movie
 
 import (
-	"strings"
 	"time"
 	"vk-backend/internal/domain"
 )
 
 type Filter struct {
-	name        *string
-	releaseDate *time.Time
-	rating      *float64
+	query           *string
+	name            *string
+	releaseDateFrom *time.Time
+	releaseDateTo   *time.Time
+	ratingMin       *float64
+	ratingMax       *float64
+	actor           *int
+	collection      *domain.UserCollection
+	genres          []int
+	allGenres       bool
+	updatedSince    *time.Time
 }
 
 func NewFilter() *Filter {
 	return &Filter{}
 }
 
+// WithQuery searches movies by words of title, description and names of actors
+func (f *Filter) WithQuery(query string) *Filter {
+	f.query = &query
+	return f
+}
+
 func (f *Filter) WithTitle(title string) *Filter {
 	f.name = &title
 	return f
 }
 
-func (f *Filter) WithReleaseDate(releaseDate time.Time) *Filter {
-	f.releaseDate = &releaseDate
+// WithReleaseDateFrom keeps movies released on or after the date
+func (f *Filter) WithReleaseDateFrom(releaseDate time.Time) *Filter {
+	f.releaseDateFrom = &releaseDate
 	return f
 }
 
-func (f *Filter) WithRating(rating float64) *Filter {
-	f.rating = &rating
+// WithReleaseDateTo keeps movies released on or before the date
+func (f *Filter) WithReleaseDateTo(releaseDate time.Time) *Filter {
+	f.releaseDateTo = &releaseDate
 	return f
 }
 
-func FilterMovies(movies []*domain.Movie, filter *Filter) []*domain.Movie {
-	res := make([]*domain.Movie, 0, len(movies))
-	if filter == nil {
-		return movies
-	}
-	for _, movie := range movies {
-		if filter.name != nil &&
-			!strings.Contains(strings.ToLower(movie.Title), strings.ToLower(*filter.name)) &&
-			!searchActor(movie.Actors, *filter.name) {
-			continue
-		}
-		if filter.releaseDate != nil && movie.ReleaseDate.Before(*filter.releaseDate) {
-			continue
-		}
-		if filter.rating != nil && movie.Rating < *filter.rating {
-			continue
+func (f *Filter) WithRatingMin(rating float64) *Filter {
+	f.ratingMin = &rating
+	return f
+}
+
+func (f *Filter) WithRatingMax(rating float64) *Filter {
+	f.ratingMax = &rating
+	return f
+}
+
+// WithActor keeps movies the actor is credited in
+func (f *Filter) WithActor(id int) *Filter {
+	f.actor = &id
+	return f
+}
+
+// WithCollection keeps movies in the collection of the user
+func (f *Filter) WithCollection(c domain.UserCollection) *Filter {
+	f.collection = &c
+	return f
+}
+
+// WithGenres keeps movies of any of the genres, or of all of them when all is set
+func (f *Filter) WithGenres(ids []int, all bool) *Filter {
+	f.genres = f.genres[:0]
+	seen := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			f.genres = append(f.genres, id)
 		}
-		res = append(res, movie)
 	}
+	f.allGenres = all
+	return f
+}
 
-	return res
+// WithUpdatedSince keeps movies changed at or after the time, so clients can sync only the changes
+func (f *Filter) WithUpdatedSince(t time.Time) *Filter {
+	f.updatedSince = &t
+	return f
 }
 
-func searchActor(actors []*domain.Actor, name string) bool {
-	for _, actor := range actors {
-		if strings.Contains(strings.ToLower(actor.Name), strings.ToLower(name)) {
-			return true
+func (f *Filter) validate() error {
+	if f == nil {
+		return nil
+	}
+	for _, rating := range []*float64{f.ratingMin, f.ratingMax} {
+		if rating != nil && (*rating < 0 || *rating > 10) {
+			return domain.ErrInvalidRating
 		}
 	}
-	return false
+	if f.ratingMin != nil && f.ratingMax != nil && *f.ratingMin > *f.ratingMax {
+		return domain.ErrInvalidRatingRange
+	}
+	if f.releaseDateFrom != nil && f.releaseDateTo != nil && f.releaseDateFrom.After(*f.releaseDateTo) {
+		return domain.ErrInvalidDateRange
+	}
+	if f.collection != nil && !f.collection.Collection.Valid() {
+		return domain.ErrCollectionNotExists
+	}
+
+	return nil
+}
+
+// params converts the filter to the form the repository applies in SQL, nil filter matches every movie
+func (f *Filter) params() domain.MovieFilter {
+	if f == nil {
+		return domain.MovieFilter{}
+	}
+
+	return domain.MovieFilter{
+		Query:           f.query,
+		Title:           f.name,
+		ReleaseDateFrom: f.releaseDateFrom,
+		ReleaseDateTo:   f.releaseDateTo,
+		RatingMin:       f.ratingMin,
+		RatingMax:       f.ratingMax,
+		Actor:           f.actor,
+		Collection:      f.collection,
+		Genres:          f.genres,
+		AllGenres:       f.allGenres,
+		UpdatedSince:    f.updatedSince,
+	}
+}
+
+// searchTerm returns the text user searched for, if any
+func (f *Filter) searchTerm() string {
+	switch {
+	case f == nil:
+		return ""
+	case f.name != nil:
+		return *f.name
+	case f.query != nil:
+		return *f.query
+	}
+	return ""
 }
@@ -9,13 +9,36 @@ import (
 )
 
 type MovieService interface {
-	AddMovie(ctx context.Context, title string, description string, releaseDate time.Time, rating float64, actors []*domain.Actor) (*domain.Movie, error)
-	AddActorToMovie(ctx context.Context, actorId int, movieId int) error
+	AddMovie(ctx context.Context, title string, description string, releaseDate time.Time, rating float64, actors []*domain.CastMember, genres []*domain.Genre) (*domain.Movie, error)
+	// AddActorToMovie, RemoveActorFromMovie and ReplaceCast change the cast of the movie of the given version
+	// and return the new version, they fail with domain.ErrVersionMismatch if the movie was changed since that version
+	AddActorToMovie(ctx context.Context, actorId int, movieId int, character string, billing int, version int) (int, error)
+	RemoveActorFromMovie(ctx context.Context, actorId int, movieId int, version int) (int, error)
+	ReplaceCast(ctx context.Context, movieId int, cast []*domain.CastMember, version int) (int, error)
 	GetMovieById(ctx context.Context, id int) (*domain.Movie, error)
 	GetActorsByMovieId(ctx context.Context, movieId int) ([]*domain.Actor, error)
-	ListMovies(ctx context.Context, filter *Filter, sorting SortBy) ([]*domain.Movie, error)
+	ListMovies(ctx context.Context, filter *Filter, sorting Sorting, page domain.Page) ([]*domain.Movie, string, error)
+	SuggestSpellings(ctx context.Context, filter *Filter) ([]string, error)
+	SimilarMovies(ctx context.Context, movieId int, limit int) ([]*domain.Movie, error)
+	// ExportMovies streams the whole catalog with casts and genres to fn, it stops at the first error of fn
+	ExportMovies(ctx context.Context, fn func(*domain.Movie) error) error
 	UpdateMovie(ctx context.Context, new *domain.Movie) error
-	DeleteMovie(ctx context.Context, id int) error
+	// DeleteMovie moves the movie to the trash unless it was changed since the version
+	DeleteMovie(ctx context.Context, id int, version int) error
+
+	// ListDeletedMovies returns a page of movies in the trash, the most recently deleted first
+	ListDeletedMovies(ctx context.Context, page domain.Page) ([]*domain.Movie, string, error)
+	RestoreMovie(ctx context.Context, id int) error
+	// PurgeMovie deletes the movie in the trash for good, movies must be deleted before they can be purged
+	PurgeMovie(ctx context.Context, id int) error
+
+	// ListRevisions returns a page of revisions of the movie without their data, the latest first
+	ListRevisions(ctx context.Context, movieId int, page domain.Page) ([]*domain.Revision, string, error)
+	GetRevision(ctx context.Context, movieId int, number int) (*domain.Revision, error)
+	// RestoreRevision updates the movie of the given version to the state of the revision and returns the new version
+	RestoreRevision(ctx context.Context, movieId int, number int, version int) (int, error)
+	// DiffRevisions returns fields changed from one revision to another
+	DiffRevisions(ctx context.Context, movieId int, from int, to int) ([]domain.FieldChange, error)
 }
 
 type movieService struct {
@@ -28,12 +51,18 @@ func NewService(repo repository.MovieRepository) MovieService {
 	}
 }
 
-func (s *movieService) AddMovie(ctx context.Context, title string, description string, releaseDate time.Time, rating float64, actors []*domain.Actor) (*domain.Movie, error) {
-	err := validateMovieData(title, description, releaseDate, rating)
+func (s *movieService) AddMovie(ctx context.Context, title string, description string, releaseDate time.Time, rating float64, actors []*domain.CastMember, genres []*domain.Genre) (*domain.Movie, error) {
+	err := ValidateMovieData(title, description, releaseDate, rating)
 	if err != nil {
 		return nil, err
 	}
-	movie, err := s.repo.AddMovie(ctx, title, description, releaseDate, rating, actors)
+	if err := validateCast(actors); err != nil {
+		return nil, err
+	}
+	if err := validateGenres(genres); err != nil {
+		return nil, err
+	}
+	movie, err := s.repo.AddMovie(ctx, title, description, releaseDate, rating, actors, genres)
 	if err != nil {
 		return nil, err
 	}
@@ -41,46 +70,127 @@ func (s *movieService) AddMovie(ctx context.Context, title string, description s
 	return movie, nil
 }
 
-func (s *movieService) AddActorToMovie(ctx context.Context, actorId int, movieId int) error {
+// AddActorToMovie credits the actor as the character, zero billing puts the actor after the rest of the cast
+func (s *movieService) AddActorToMovie(ctx context.Context, actorId int, movieId int, character string, billing int, version int) (int, error) {
 	if actorId <= 0 {
-		return domain.ErrActorNotExists
+		return 0, domain.ErrActorNotExists
 	}
 	if movieId <= 0 {
-		return domain.ErrMovieNotExists
+		return 0, domain.ErrMovieNotExists
+	}
+	if err := validateCredit(character, billing); err != nil {
+		return 0, err
 	}
 	ok, err := s.repo.ActorExists(ctx, actorId)
 	if err != nil {
-		return fmt.Errorf("actor service can't check if actor exists: %w", err)
+		return 0, fmt.Errorf("actor service can't check if actor exists: %w", err)
 	}
 	if !ok {
-		return domain.ErrActorNotExists
+		return 0, domain.ErrActorNotExists
 	}
 
 	ok, err = s.repo.MovieExists(ctx, movieId)
 	if err != nil {
-		return fmt.Errorf("actor service can't check if movie exists: %w", err)
+		return 0, fmt.Errorf("actor service can't check if movie exists: %w", err)
 	}
 	if !ok {
-		return domain.ErrMovieNotExists
+		return 0, domain.ErrMovieNotExists
 	}
 
 	movie, err := s.repo.GetMovieById(ctx, movieId)
 	if err != nil {
-		return fmt.Errorf("actor service can't get movie by id: %w", err)
+		return 0, fmt.Errorf("actor service can't get movie by id: %w", err)
 	}
 
+	// credits of actors in the trash aren't in the cast, the repository bills them after it
 	for _, actor := range movie.Actors {
 		if actor.Id == actorId {
-			return domain.ErrActorAlreadyInMovie
+			return 0, domain.ErrActorAlreadyInMovie
+		}
+		if billing != 0 && actor.Billing == billing {
+			return 0, domain.ErrBillingTaken
 		}
 	}
 
-	err = s.repo.AddActorToMovie(ctx, actorId, movieId)
+	version, err = s.repo.AddActorToMovie(ctx, actorId, movieId, character, billing, version)
 	if err != nil {
-		return fmt.Errorf("actor service can't add actor to movie: %w", err)
+		return 0, fmt.Errorf("actor service can't add actor to movie: %w", err)
 	}
 
-	return nil
+	return version, nil
+}
+
+func (s *movieService) RemoveActorFromMovie(ctx context.Context, actorId int, movieId int, version int) (int, error) {
+	if actorId <= 0 {
+		return 0, domain.ErrActorNotExists
+	}
+	if movieId <= 0 {
+		return 0, domain.ErrMovieNotExists
+	}
+	ok, err := s.repo.MovieExists(ctx, movieId)
+	if err != nil {
+		return 0, fmt.Errorf("movie service can't check if movie exists: %w", err)
+	}
+	if !ok {
+		return 0, domain.ErrMovieNotExists
+	}
+
+	movie, err := s.repo.GetMovieById(ctx, movieId)
+	if err != nil {
+		return 0, fmt.Errorf("movie service can't get movie by id: %w", err)
+	}
+
+	inMovie := false
+	for _, actor := range movie.Actors {
+		if actor.Id == actorId {
+			inMovie = true
+			break
+		}
+	}
+	if !inMovie {
+		return 0, domain.ErrActorNotInMovie
+	}
+
+	version, err = s.repo.RemoveActorFromMovie(ctx, actorId, movieId, version)
+	if err != nil {
+		return 0, fmt.Errorf("movie service can't remove actor from movie: %w", err)
+	}
+
+	return version, nil
+}
+
+// ReplaceCast sets the whole cast of the movie, actors without billing are billed after the rest in the order of the list
+func (s *movieService) ReplaceCast(ctx context.Context, movieId int, cast []*domain.CastMember, version int) (int, error) {
+	if movieId <= 0 {
+		return 0, domain.ErrMovieNotExists
+	}
+	if err := validateCast(cast); err != nil {
+		return 0, err
+	}
+	ok, err := s.repo.MovieExists(ctx, movieId)
+	if err != nil {
+		return 0, fmt.Errorf("movie service can't check if movie exists: %w", err)
+	}
+	if !ok {
+		return 0, domain.ErrMovieNotExists
+	}
+
+	for _, actor := range cast {
+		ok, err := s.repo.ActorExists(ctx, actor.Id)
+		if err != nil {
+			return 0, fmt.Errorf("movie service can't check if actor exists: %w", err)
+		}
+		if !ok {
+			return 0, domain.ErrActorNotExists
+		}
+	}
+
+	version, err = s.repo.ReplaceCast(ctx, movieId, cast, version)
+	if err != nil {
+		return 0, fmt.Errorf("movie service can't replace cast: %w", err)
+	}
+
+	return version, nil
 }
 
 func (s *movieService) GetMovieById(ctx context.Context, id int) (*domain.Movie, error) {
@@ -123,14 +233,47 @@ func (s *movieService) GetActorsByMovieId(ctx context.Context, movieId int) ([]*
 	return actors, nil
 }
 
-func (s *movieService) ListMovies(ctx context.Context, filter *Filter, sorting SortBy) ([]*domain.Movie, error) {
-	movies, err := s.repo.ListMovies(ctx)
+func (s *movieService) ListMovies(ctx context.Context, filter *Filter, sorting Sorting, page domain.Page) ([]*domain.Movie, string, error) {
+	if err := filter.validate(); err != nil {
+		return nil, "", err
+	}
+	sorting = resolveSorting(sorting, filter)
+	if err := validateSorting(sorting, filter); err != nil {
+		return nil, "", err
+	}
+	if filter != nil && filter.actor != nil {
+		ok, err := s.repo.ActorExists(ctx, *filter.actor)
+		if err != nil {
+			return nil, "", fmt.Errorf("movie service can't check if actor exists: %w", err)
+		}
+		if !ok {
+			return nil, "", domain.ErrActorNotExists
+		}
+	}
+
+	movies, next, err := s.repo.ListMovies(ctx, filter.params(), sorting, page.Normalize())
 	if err != nil {
-		return nil, fmt.Errorf("movie service can't list movies: %w", err)
+		return nil, "", fmt.Errorf("movie service can't list movies: %w", err)
 	}
-	movies = SortMovies(FilterMovies(movies, filter), sorting)
 
-	return movies, nil
+	return movies, next, nil
+}
+
+const suggestionsLimit = 5
+
+// SuggestSpellings returns titles and names closest to the searched term, so a search that found nothing can be corrected
+func (s *movieService) SuggestSpellings(ctx context.Context, filter *Filter) ([]string, error) {
+	term := filter.searchTerm()
+	if term == "" {
+		return nil, nil
+	}
+
+	suggestions, err := s.repo.SuggestSpellings(ctx, term, suggestionsLimit)
+	if err != nil {
+		return nil, fmt.Errorf("movie service can't suggest spellings: %w", err)
+	}
+
+	return suggestions, nil
 }
 
 func (s *movieService) UpdateMovie(ctx context.Context, new *domain.Movie) error {
@@ -144,6 +287,15 @@ func (s *movieService) UpdateMovie(ctx context.Context, new *domain.Movie) error
 	if !ok {
 		return domain.ErrMovieNotExists
 	}
+	if err := ValidateMovieData(new.Title, new.Description, new.ReleaseDate, new.Rating); err != nil {
+		return err
+	}
+	if err := validateCast(new.Actors); err != nil {
+		return err
+	}
+	if err := validateGenres(new.Genres); err != nil {
+		return err
+	}
 
 	err = s.repo.UpdateMovie(ctx, new)
 	if err != nil {
@@ -153,7 +305,7 @@ func (s *movieService) UpdateMovie(ctx context.Context, new *domain.Movie) error
 	return nil
 }
 
-func (s *movieService) DeleteMovie(ctx context.Context, id int) error {
+func (s *movieService) DeleteMovie(ctx context.Context, id int, version int) error {
 	if id <= 0 {
 		return domain.ErrMovieNotExists
 	}
@@ -165,7 +317,7 @@ func (s *movieService) DeleteMovie(ctx context.Context, id int) error {
 		return domain.ErrMovieNotExists
 	}
 
-	err = s.repo.DeleteMovie(ctx, id)
+	err = s.repo.DeleteMovie(ctx, id, version)
 	if err != nil {
 		return fmt.Errorf("movie service can't delete movie: %w", err)
 	}
@@ -173,7 +325,37 @@ func (s *movieService) DeleteMovie(ctx context.Context, id int) error {
 	return nil
 }
 
-func validateMovieData(title, description string, date time.Time, rating float64) error {
+// SimilarMovies recommends movies sharing the cast with the given one, zero limit means the default page size
+func (s *movieService) SimilarMovies(ctx context.Context, movieId int, limit int) ([]*domain.Movie, error) {
+	if movieId <= 0 {
+		return nil, domain.ErrMovieNotExists
+	}
+	ok, err := s.repo.MovieExists(ctx, movieId)
+	if err != nil {
+		return nil, fmt.Errorf("movie service can't check if movie exists: %w", err)
+	}
+	if !ok {
+		return nil, domain.ErrMovieNotExists
+	}
+
+	movies, err := s.repo.SimilarMovies(ctx, movieId, domain.Page{Limit: limit}.Normalize().Limit)
+	if err != nil {
+		return nil, fmt.Errorf("movie service can't get similar movies: %w", err)
+	}
+
+	return movies, nil
+}
+
+func (s *movieService) ExportMovies(ctx context.Context, fn func(*domain.Movie) error) error {
+	if err := s.repo.ExportMovies(ctx, fn); err != nil {
+		return fmt.Errorf("movie service can't export movies: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateMovieData checks the movie the same way for the API and bulk imports
+func ValidateMovieData(title, description string, date time.Time, rating float64) error {
 	if title == "" {
 		return domain.ErrEmptyTitle
 	}
@@ -195,3 +377,65 @@ func validateMovieData(title, description string, date time.Time, rating float64
 
 	return nil
 }
+
+// validateCast checks the credits, actors and billings can't repeat.
+// Actors without billing are billed after the highest billing in the order of the list.
+func validateCast(cast []*domain.CastMember) error {
+	actors := make(map[int]struct{}, len(cast))
+	billings := make(map[int]struct{}, len(cast))
+	last := 0
+	for _, actor := range cast {
+		if actor.Actor == nil || actor.Id <= 0 {
+			return domain.ErrActorNotExists
+		}
+		if _, ok := actors[actor.Id]; ok {
+			return domain.ErrRepeatedCredit
+		}
+		actors[actor.Id] = struct{}{}
+
+		if err := validateCredit(actor.Character, actor.Billing); err != nil {
+			return err
+		}
+		if actor.Billing == 0 {
+			continue
+		}
+		if _, ok := billings[actor.Billing]; ok {
+			return domain.ErrRepeatedCredit
+		}
+		billings[actor.Billing] = struct{}{}
+		last = max(last, actor.Billing)
+	}
+
+	for _, actor := range cast {
+		if actor.Billing == 0 {
+			last++
+			actor.Billing = last
+		}
+	}
+
+	return nil
+}
+
+// validateGenres checks that a genre is given once, otherwise it would be added to the movie twice
+func validateGenres(genres []*domain.Genre) error {
+	seen := make(map[int]struct{}, len(genres))
+	for _, genre := range genres {
+		if _, ok := seen[genre.Id]; ok {
+			return domain.ErrRepeatedGenre
+		}
+		seen[genre.Id] = struct{}{}
+	}
+
+	return nil
+}
+
+func validateCredit(character string, billing int) error {
+	if len(character) > 150 {
+		return domain.ErrTooLongCharacter
+	}
+	if billing < 0 {
+		return domain.ErrInvalidBilling
+	}
+
+	return nil
+}
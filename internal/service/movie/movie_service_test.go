@@ -2,9 +2,9 @@ package movie
 
 import (
 	"context"
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
-	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -48,7 +48,7 @@ func TestMovieService_AddMovie(t *testing.T) {
 	releaseDate := time.Now()
 	repo.
 		EXPECT().
-		AddMovie(gomock.Any(), "name", "description", releaseDate, 9.0, nil).
+		AddMovie(gomock.Any(), "name", "description", releaseDate, 9.0, nil, nil).
 		Return(&domain.Movie{
 			Id:          1,
 			Title:       "name",
@@ -58,7 +58,7 @@ func TestMovieService_AddMovie(t *testing.T) {
 			Actors:      nil,
 		}, nil)
 
-	movie, err := service.AddMovie(context.Background(), "name", "description", releaseDate, 9.0, nil)
+	movie, err := service.AddMovie(context.Background(), "name", "description", releaseDate, 9.0, nil, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, &domain.Movie{
 		Id:          1,
@@ -78,17 +78,23 @@ func TestMovieService_AddMovie_WithActors(t *testing.T) {
 	service := NewService(repo)
 
 	releaseDate := time.Now()
-	actors := []*domain.Actor{
+	actors := []*domain.CastMember{
 		{
-			Id:        1,
-			Name:      "name",
-			Gender:    1,
-			BirthDate: time.Now(),
+			Actor: &domain.Actor{
+				Id:        1,
+				Name:      "name",
+				Gender:    1,
+				BirthDate: time.Now(),
+			},
+			Character: "character",
 		},
 	}
 	repo.
 		EXPECT().
-		AddMovie(gomock.Any(), "name", "description", releaseDate, 9.0, actors).
+		AddMovie(gomock.Any(), "name", "description", releaseDate, 9.0, actors, nil).
+		Do(func(_ context.Context, _, _ string, _ time.Time, _ float64, actors []*domain.CastMember, _ []*domain.Genre) {
+			assert.Equal(t, 1, actors[0].Billing)
+		}).
 		Return(&domain.Movie{
 			Id:          1,
 			Title:       "name",
@@ -98,7 +104,7 @@ func TestMovieService_AddMovie_WithActors(t *testing.T) {
 			Actors:      actors,
 		}, nil)
 
-	movie, err := service.AddMovie(context.Background(), "name", "description", releaseDate, 9.0, actors)
+	movie, err := service.AddMovie(context.Background(), "name", "description", releaseDate, 9.0, actors, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, &domain.Movie{
 		Id:          1,
@@ -118,27 +124,37 @@ func TestMovieService_AddMovie_InvalidData(t *testing.T) {
 	service := NewService(repo)
 
 	releaseDate := time.Now()
-	movie, err := service.AddMovie(context.Background(), "", "description", releaseDate, 9.0, nil)
+	movie, err := service.AddMovie(context.Background(), "", "description", releaseDate, 9.0, nil, nil)
 	assert.ErrorIs(t, err, domain.ErrEmptyTitle)
 	assert.Nil(t, movie)
 
-	movie, err = service.AddMovie(context.Background(), "name", "", releaseDate, 9.0, nil)
+	movie, err = service.AddMovie(context.Background(), "name", "", releaseDate, 9.0, nil, nil)
 	assert.ErrorIs(t, err, domain.ErrEmptyDescription)
 	assert.Nil(t, movie)
 
-	movie, err = service.AddMovie(context.Background(), "name", "description", releaseDate, -2.0, nil)
+	movie, err = service.AddMovie(context.Background(), "name", "description", releaseDate, -2.0, nil, nil)
 	assert.ErrorIs(t, err, domain.ErrInvalidRating)
 	assert.Nil(t, movie)
 
 	longTitle := strings.Repeat("a", 256)
-	movie, err = service.AddMovie(context.Background(), longTitle, "description", releaseDate, 9.0, nil)
+	movie, err = service.AddMovie(context.Background(), longTitle, "description", releaseDate, 9.0, nil, nil)
 	assert.ErrorIs(t, err, domain.ErrTooLongTitle)
 	assert.Nil(t, movie)
 
 	longDescription := strings.Repeat("a", 4096)
-	movie, err = service.AddMovie(context.Background(), "name", longDescription, releaseDate, 9.0, nil)
+	movie, err = service.AddMovie(context.Background(), "name", longDescription, releaseDate, 9.0, nil, nil)
 	assert.ErrorIs(t, err, domain.ErrTooLongDescription)
 	assert.Nil(t, movie)
+
+	actors := []*domain.CastMember{{Actor: &domain.Actor{Id: 1}, Character: strings.Repeat("a", 151)}}
+	movie, err = service.AddMovie(context.Background(), "name", "description", releaseDate, 9.0, actors, nil)
+	assert.ErrorIs(t, err, domain.ErrTooLongCharacter)
+	assert.Nil(t, movie)
+
+	actors = []*domain.CastMember{{Actor: &domain.Actor{Id: 1}, Billing: -1}}
+	movie, err = service.AddMovie(context.Background(), "name", "description", releaseDate, 9.0, actors, nil)
+	assert.ErrorIs(t, err, domain.ErrInvalidBilling)
+	assert.Nil(t, movie)
 }
 
 func TestMovieService_AddActorToMovie(t *testing.T) {
@@ -173,11 +189,41 @@ func TestMovieService_AddActorToMovie(t *testing.T) {
 
 	repo.
 		EXPECT().
-		AddActorToMovie(gomock.Any(), 1, 1).
-		Return(nil)
+		AddActorToMovie(gomock.Any(), 1, 1, "character", 2, 3).
+		Return(4, nil)
 
-	err := service.AddActorToMovie(context.Background(), 1, 1)
+	version, err := service.AddActorToMovie(context.Background(), 1, 1, "character", 2, 3)
 	assert.NoError(t, err)
+	assert.Equal(t, 4, version)
+}
+
+func TestMovieService_AddActorToMovie_BillingTaken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		ActorExists(gomock.Any(), 1).
+		Return(true, nil)
+
+	repo.
+		EXPECT().
+		MovieExists(gomock.Any(), 1).
+		Return(true, nil)
+
+	repo.
+		EXPECT().
+		GetMovieById(gomock.Any(), 1).
+		Return(&domain.Movie{
+			Id:     1,
+			Actors: []*domain.CastMember{{Actor: &domain.Actor{Id: 2}, Billing: 2}},
+		}, nil)
+
+	_, err := service.AddActorToMovie(context.Background(), 1, 1, "character", 2, 3)
+	assert.ErrorIs(t, err, domain.ErrBillingTaken)
 }
 
 func TestMovieService_AddActorToMovie_ActorNotExists(t *testing.T) {
@@ -192,7 +238,7 @@ func TestMovieService_AddActorToMovie_ActorNotExists(t *testing.T) {
 		ActorExists(gomock.Any(), 1).
 		Return(false, nil)
 
-	err := service.AddActorToMovie(context.Background(), 1, 1)
+	_, err := service.AddActorToMovie(context.Background(), 1, 1, "", 0, 3)
 	assert.ErrorIs(t, err, domain.ErrActorNotExists)
 }
 
@@ -213,10 +259,130 @@ func TestMovieService_AddActorToMovie_MovieNotExists(t *testing.T) {
 		MovieExists(gomock.Any(), 1).
 		Return(false, nil)
 
-	err := service.AddActorToMovie(context.Background(), 1, 1)
+	_, err := service.AddActorToMovie(context.Background(), 1, 1, "", 0, 3)
 	assert.ErrorIs(t, err, domain.ErrMovieNotExists)
 }
 
+func TestMovieService_RemoveActorFromMovie(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		MovieExists(gomock.Any(), 1).
+		Return(true, nil)
+
+	repo.
+		EXPECT().
+		GetMovieById(gomock.Any(), 1).
+		Return(&domain.Movie{
+			Id:     1,
+			Actors: []*domain.CastMember{{Actor: &domain.Actor{Id: 2}, Billing: 1}},
+		}, nil)
+
+	repo.
+		EXPECT().
+		RemoveActorFromMovie(gomock.Any(), 2, 1, 3).
+		Return(4, nil)
+
+	version, err := service.RemoveActorFromMovie(context.Background(), 2, 1, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, version)
+}
+
+func TestMovieService_RemoveActorFromMovie_NotInMovie(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		MovieExists(gomock.Any(), 1).
+		Return(true, nil)
+
+	repo.
+		EXPECT().
+		GetMovieById(gomock.Any(), 1).
+		Return(&domain.Movie{Id: 1}, nil)
+
+	_, err := service.RemoveActorFromMovie(context.Background(), 2, 1, 3)
+	assert.ErrorIs(t, err, domain.ErrActorNotInMovie)
+}
+
+func TestMovieService_ReplaceCast(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	cast := []*domain.CastMember{
+		{Actor: &domain.Actor{Id: 2}, Character: "second", Billing: 2},
+		{Actor: &domain.Actor{Id: 3}, Character: "first"},
+	}
+
+	repo.
+		EXPECT().
+		MovieExists(gomock.Any(), 1).
+		Return(true, nil)
+
+	repo.
+		EXPECT().
+		ActorExists(gomock.Any(), gomock.Any()).
+		Return(true, nil).
+		Times(2)
+
+	repo.
+		EXPECT().
+		ReplaceCast(gomock.Any(), 1, cast, 3).
+		Return(4, nil)
+
+	version, err := service.ReplaceCast(context.Background(), 1, cast, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, version)
+	assert.Equal(t, 2, cast[0].Billing)
+	// billed after the highest billing rather than by the position in the list
+	assert.Equal(t, 3, cast[1].Billing)
+}
+
+func TestMovieService_ReplaceCast_InvalidCast(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	_, err := service.ReplaceCast(context.Background(), 1, []*domain.CastMember{
+		{Actor: &domain.Actor{Id: 2}},
+		{Actor: &domain.Actor{Id: 2}},
+	}, 3)
+	assert.ErrorIs(t, err, domain.ErrRepeatedCredit)
+
+	_, err = service.ReplaceCast(context.Background(), 1, []*domain.CastMember{
+		{Actor: &domain.Actor{Id: 2}, Billing: 1},
+		{Actor: &domain.Actor{Id: 3}, Billing: 1},
+	}, 3)
+	assert.ErrorIs(t, err, domain.ErrRepeatedCredit)
+
+	repo.
+		EXPECT().
+		MovieExists(gomock.Any(), 1).
+		Return(true, nil)
+
+	repo.
+		EXPECT().
+		ActorExists(gomock.Any(), 2).
+		Return(false, nil)
+
+	_, err = service.ReplaceCast(context.Background(), 1, []*domain.CastMember{{Actor: &domain.Actor{Id: 2}}}, 3)
+	assert.ErrorIs(t, err, domain.ErrActorNotExists)
+}
+
 func TestMovieService_GetMovieById(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -399,6 +565,52 @@ func TestMovieService_UpdateMovie_NotExists(t *testing.T) {
 	assert.ErrorIs(t, err, domain.ErrMovieNotExists)
 }
 
+func TestMovieService_UpdateMovie_ZeroRating(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	movie := &domain.Movie{Id: 1, Title: "name", Description: "description", ReleaseDate: time.Now(), Rating: 0}
+
+	repo.
+		EXPECT().
+		MovieExists(gomock.Any(), 1).
+		Return(true, nil)
+
+	repo.
+		EXPECT().
+		UpdateMovie(gomock.Any(), movie).
+		Return(nil)
+
+	err := service.UpdateMovie(context.Background(), movie)
+	assert.NoError(t, err)
+}
+
+func TestMovieService_UpdateMovie_InvalidData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		MovieExists(gomock.Any(), 1).
+		Return(true, nil).
+		Times(3)
+
+	err := service.UpdateMovie(context.Background(), &domain.Movie{Id: 1, Description: "description", ReleaseDate: time.Now()})
+	assert.ErrorIs(t, err, domain.ErrEmptyTitle)
+
+	err = service.UpdateMovie(context.Background(), &domain.Movie{Id: 1, Title: "name", ReleaseDate: time.Now()})
+	assert.ErrorIs(t, err, domain.ErrEmptyDescription)
+
+	err = service.UpdateMovie(context.Background(), &domain.Movie{Id: 1, Title: "name", Description: "description", ReleaseDate: time.Now(), Rating: 11})
+	assert.ErrorIs(t, err, domain.ErrInvalidRating)
+}
+
 func TestMovieService_DeleteMovie(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -413,10 +625,10 @@ func TestMovieService_DeleteMovie(t *testing.T) {
 
 	repo.
 		EXPECT().
-		DeleteMovie(gomock.Any(), 1).
+		DeleteMovie(gomock.Any(), 1, 2).
 		Return(nil)
 
-	err := service.DeleteMovie(context.Background(), 1)
+	err := service.DeleteMovie(context.Background(), 1, 2)
 	assert.NoError(t, err)
 }
 
@@ -432,10 +644,59 @@ func TestMovieService_DeleteMovie_NotExists(t *testing.T) {
 		MovieExists(gomock.Any(), 1).
 		Return(false, nil)
 
-	err := service.DeleteMovie(context.Background(), 1)
+	err := service.DeleteMovie(context.Background(), 1, 2)
 	assert.ErrorIs(t, err, domain.ErrMovieNotExists)
 }
 
+func TestMovieService_UpdateMovie_VersionMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	movie := &domain.Movie{Id: 1, Title: "title", Description: "description", ReleaseDate: time.Now(), Version: 2}
+	repo.
+		EXPECT().
+		MovieExists(gomock.Any(), 1).
+		Return(true, nil).
+		Times(2)
+	repo.
+		EXPECT().
+		UpdateMovie(gomock.Any(), movie).
+		Return(domain.ErrVersionMismatch)
+	repo.
+		EXPECT().
+		DeleteMovie(gomock.Any(), 1, 2).
+		Return(domain.ErrVersionMismatch)
+
+	err := service.UpdateMovie(context.Background(), movie)
+	assert.ErrorIs(t, err, domain.ErrVersionMismatch)
+
+	err = service.DeleteMovie(context.Background(), 1, 2)
+	assert.ErrorIs(t, err, domain.ErrVersionMismatch)
+}
+
+func TestMovieService_ReplaceCast_VersionMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		MovieExists(gomock.Any(), 1).
+		Return(true, nil)
+	repo.
+		EXPECT().
+		ReplaceCast(gomock.Any(), 1, []*domain.CastMember{}, 2).
+		Return(0, domain.ErrVersionMismatch)
+
+	_, err := service.ReplaceCast(context.Background(), 1, []*domain.CastMember{}, 2)
+	assert.ErrorIs(t, err, domain.ErrVersionMismatch)
+}
+
 func TestMovieService_ListMovies(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -443,21 +704,76 @@ func TestMovieService_ListMovies(t *testing.T) {
 	repo := mocks.NewMockMovieRepository(ctrl)
 	service := NewService(repo)
 
-	movies := testMovies()
+	expected := testMovies()
 
 	repo.
 		EXPECT().
-		ListMovies(gomock.Any()).
-		Return(movies, nil)
+		ListMovies(gomock.Any(), domain.MovieFilter{}, domain.MovieSort{{Field: domain.MovieSortByRating, Desc: true}}, domain.Page{Limit: domain.DefaultPageLimit}).
+		Return(expected, "", nil)
 
-	movies, err := service.ListMovies(context.Background(), nil, DefaultSort)
+	movies, next, err := service.ListMovies(context.Background(), nil, nil, domain.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, movies)
+	assert.Empty(t, next)
+}
+
+func TestMovieService_ListMovies_WithFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	title, date, rating := "title", time.Now(), 4.0
+	expected := testMovies()[:2]
+
+	repo.
+		EXPECT().
+		ListMovies(gomock.Any(), domain.MovieFilter{
+			Title:           &title,
+			ReleaseDateFrom: &date,
+			RatingMin:       &rating,
+		}, domain.MovieSort{{Field: domain.MovieSortByTitle}}, domain.Page{Limit: 2, Cursor: "cursor"}).
+		Return(expected, "next", nil)
+
+	filter := NewFilter().WithTitle(title).WithReleaseDateFrom(date).WithRatingMin(rating)
+	movies, next, err := service.ListMovies(context.Background(), filter, Sorting{NewSortKey(SortByTitle)}, domain.Page{Limit: 2, Cursor: "cursor"})
 	assert.NoError(t, err)
-	expected := movies
-	sort.Slice(expected, func(i, j int) bool {
-		return expected[i].Rating > expected[j].Rating
-	})
 	assert.Equal(t, expected, movies)
+	assert.Equal(t, "next", next)
+}
+
+func TestMovieService_ListMovies_LimitCapped(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		ListMovies(gomock.Any(), domain.MovieFilter{}, domain.MovieSort{{Field: domain.MovieSortByRating, Desc: true}}, domain.Page{Limit: domain.MaxPageLimit}).
+		Return(nil, "", nil)
+
+	_, _, err := service.ListMovies(context.Background(), nil, nil, domain.Page{Limit: domain.MaxPageLimit + 1})
+	assert.NoError(t, err)
+}
+
+func TestMovieService_ListMovies_InvalidCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		ListMovies(gomock.Any(), domain.MovieFilter{}, domain.MovieSort{{Field: domain.MovieSortByRating, Desc: true}}, domain.Page{Limit: domain.DefaultPageLimit, Cursor: "bad"}).
+		Return(nil, "", domain.ErrInvalidCursor)
+
+	movies, _, err := service.ListMovies(context.Background(), nil, nil, domain.Page{Cursor: "bad"})
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+	assert.Nil(t, movies)
 }
 
 func TestFilterBuilder(t *testing.T) {
@@ -465,47 +781,367 @@ func TestFilterBuilder(t *testing.T) {
 	f := NewFilter()
 	assert.NotNil(t, f)
 	assert.Nil(t, f.name)
-	assert.Nil(t, f.releaseDate)
-	assert.Nil(t, f.rating)
+	assert.Nil(t, f.releaseDateFrom)
+	assert.Nil(t, f.releaseDateTo)
+	assert.Nil(t, f.ratingMin)
+	assert.Nil(t, f.ratingMax)
 
 	title, date, rating := "name", time.Now(), 5.0
-	f = f.WithTitle(title).WithReleaseDate(date).WithRating(rating)
+	f = f.WithTitle(title).WithReleaseDateFrom(date).WithReleaseDateTo(date).WithRatingMin(rating).WithRatingMax(rating)
 
 	assert.Equal(t, title, *f.name)
-	assert.Equal(t, date, *f.releaseDate)
-	assert.Equal(t, rating, *f.rating)
+	assert.Equal(t, date, *f.releaseDateFrom)
+	assert.Equal(t, date, *f.releaseDateTo)
+	assert.Equal(t, rating, *f.ratingMin)
+	assert.Equal(t, rating, *f.ratingMax)
 }
 
-func TestFilterMovies(t *testing.T) {
-	movies := testMovies()
-	filter := NewFilter().WithTitle("title1")
+func TestMovieService_ListMovies_FullTextSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	query := "матрица reloaded"
+	expected := testMovies()[:1]
+	expected[0].Relevance = 0.5
+	expected[0].Headline = "<b>title1</b>. description1"
+
+	repo.
+		EXPECT().
+		ListMovies(gomock.Any(), domain.MovieFilter{Query: &query}, domain.MovieSort{{Field: domain.MovieSortByRelevance, Desc: true}}, domain.Page{Limit: domain.DefaultPageLimit}).
+		Return(expected, "", nil)
+
+	movies, _, err := service.ListMovies(context.Background(), NewFilter().WithQuery(query), nil, domain.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, movies)
+}
+
+func TestMovieService_ListMovies_UpdatedSince(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	since := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	expected := testMovies()[:1]
+
+	repo.
+		EXPECT().
+		ListMovies(gomock.Any(), domain.MovieFilter{UpdatedSince: &since}, gomock.Any(), domain.Page{Limit: domain.DefaultPageLimit}).
+		Return(expected, "", nil)
+
+	movies, _, err := service.ListMovies(context.Background(), NewFilter().WithUpdatedSince(since), nil, domain.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, movies)
+}
+
+func TestMovieService_SuggestSpellings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		SuggestSpellings(gomock.Any(), "Keanu Reevs", suggestionsLimit).
+		Return([]string{"Keanu Reeves"}, nil)
+
+	suggestions, err := service.SuggestSpellings(context.Background(), NewFilter().WithTitle("Keanu Reevs"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Keanu Reeves"}, suggestions)
+}
+
+func TestMovieService_SuggestSpellings_NoSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	suggestions, err := service.SuggestSpellings(context.Background(), NewFilter().WithRatingMin(5))
+	assert.NoError(t, err)
+	assert.Nil(t, suggestions)
+}
+
+func TestMovieService_ListMovies_MultiKeySort(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	sorting := Sorting{
+		{Field: SortByRating},
+		{Field: SortByReleaseDate, Desc: true},
+	}
+
+	repo.
+		EXPECT().
+		ListMovies(gomock.Any(), domain.MovieFilter{}, sorting, domain.Page{Limit: domain.DefaultPageLimit}).
+		Return(testMovies(), "", nil)
+
+	_, _, err := service.ListMovies(context.Background(), nil, sorting, domain.Page{})
+	assert.NoError(t, err)
+}
+
+func TestMovieService_ListMovies_DefaultFieldOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	query := "matrix"
+	repo.
+		EXPECT().
+		ListMovies(gomock.Any(), domain.MovieFilter{}, Sorting{{Field: SortByRating}}, domain.Page{Limit: domain.DefaultPageLimit}).
+		Return(testMovies(), "", nil)
+	repo.
+		EXPECT().
+		ListMovies(gomock.Any(), domain.MovieFilter{Query: &query}, Sorting{{Field: SortByRelevance}}, domain.Page{Limit: domain.DefaultPageLimit}).
+		Return(testMovies(), "", nil)
+
+	// only the direction is given, the field is the default one of the listing
+	ascending := Sorting{{Field: SortByDefault}}
+	_, _, err := service.ListMovies(context.Background(), nil, ascending, domain.Page{})
+	assert.NoError(t, err)
+	_, _, err = service.ListMovies(context.Background(), NewFilter().WithQuery(query), ascending, domain.Page{})
+	assert.NoError(t, err)
+
+	_, _, err = service.ListMovies(context.Background(), nil, Sorting{NewSortKey(SortByTitle), {Field: SortByDefault}}, domain.Page{})
+	assert.ErrorIs(t, err, domain.ErrInvalidSort)
+}
+
+func TestMovieService_ListMovies_InvalidSorting(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	_, _, err := service.ListMovies(context.Background(), nil, Sorting{NewSortKey(SortByTitle), NewSortKey(SortByTitle)}, domain.Page{})
+	assert.ErrorIs(t, err, domain.ErrInvalidSort)
 
-	filteredMovies := FilterMovies(movies, filter)
-	assert.Len(t, filteredMovies, 1)
-	assert.Equal(t, "title1", filteredMovies[0].Title)
+	_, _, err = service.ListMovies(context.Background(), NewFilter().WithTitle("title"), Sorting{NewSortKey(SortByRelevance)}, domain.Page{})
+	assert.ErrorIs(t, err, domain.ErrInvalidSort)
+}
+
+func TestMovieService_ListMovies_InvalidFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
 
-	filter = NewFilter().WithRating(4.0)
-	filteredMovies = FilterMovies(movies, filter)
-	assert.Len(t, filteredMovies, 2)
+	_, _, err := service.ListMovies(context.Background(), NewFilter().WithRatingMax(11), nil, domain.Page{})
+	assert.ErrorIs(t, err, domain.ErrInvalidRating)
+
+	_, _, err = service.ListMovies(context.Background(), NewFilter().WithRatingMin(7).WithRatingMax(5), nil, domain.Page{})
+	assert.ErrorIs(t, err, domain.ErrInvalidRatingRange)
 
-	filter = NewFilter().WithReleaseDate(movies[1].ReleaseDate)
-	filteredMovies = FilterMovies(movies, filter)
-	assert.Len(t, filteredMovies, 2)
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, _, err = service.ListMovies(context.Background(), NewFilter().WithReleaseDateFrom(from).WithReleaseDateTo(from.AddDate(0, 0, -1)), nil, domain.Page{})
+	assert.ErrorIs(t, err, domain.ErrInvalidDateRange)
 }
 
-func TestSortMovies(t *testing.T) {
-	movies := testMovies()
+func TestMovieService_AddMovie_WithGenres(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	releaseDate := time.Now()
+	genres := []*domain.Genre{{Id: 1, Name: "drama"}}
+	expected := &domain.Movie{
+		Id:          1,
+		Title:       "name",
+		Description: "description",
+		ReleaseDate: releaseDate,
+		Rating:      9.0,
+		Genres:      genres,
+	}
+	repo.
+		EXPECT().
+		AddMovie(gomock.Any(), "name", "description", releaseDate, 9.0, nil, genres).
+		Return(expected, nil)
 
-	sortedMovies := SortMovies(movies, SortByRating)
-	assert.Equal(t, 5.0, sortedMovies[0].Rating)
-	assert.Equal(t, 4.0, sortedMovies[1].Rating)
-	assert.Equal(t, 3.0, sortedMovies[2].Rating)
+	movie, err := service.AddMovie(context.Background(), "name", "description", releaseDate, 9.0, nil, genres)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, movie)
+}
 
-	sortedMovies = SortMovies(movies, SortByReleaseDate)
-	assert.True(t, movies[2].ReleaseDate.Before(movies[1].ReleaseDate) && movies[1].ReleaseDate.Before(movies[0].ReleaseDate))
+func TestMovieService_RepeatedGenre(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	releaseDate := time.Now()
+	genres := []*domain.Genre{{Id: 1, Name: "drama"}, {Id: 2, Name: "comedy"}, {Id: 1, Name: "drama"}}
+
+	_, err := service.AddMovie(context.Background(), "name", "description", releaseDate, 9.0, nil, genres)
+	assert.ErrorIs(t, err, domain.ErrRepeatedGenre)
+
+	repo.
+		EXPECT().
+		MovieExists(gomock.Any(), 1).
+		Return(true, nil)
+
+	err = service.UpdateMovie(context.Background(), &domain.Movie{Id: 1, Title: "name", Description: "description", ReleaseDate: releaseDate, Genres: genres})
+	assert.ErrorIs(t, err, domain.ErrRepeatedGenre)
+}
+
+func TestMovieService_ListMovies_ByGenres(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		ListMovies(gomock.Any(), domain.MovieFilter{Genres: []int{1, 2}, AllGenres: true}, gomock.Any(), gomock.Any()).
+		Return(testMovies(), "", nil)
+
+	_, _, err := service.ListMovies(context.Background(), NewFilter().WithGenres([]int{1, 2, 1}, true), nil, domain.Page{})
+	assert.NoError(t, err)
+}
+
+func TestMovieService_ListMovies_ByActor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	actor := 2
+	repo.
+		EXPECT().
+		ActorExists(gomock.Any(), actor).
+		Return(true, nil)
+
+	repo.
+		EXPECT().
+		ListMovies(gomock.Any(), domain.MovieFilter{Actor: &actor}, Sorting{NewSortKey(SortByReleaseDate)}, domain.Page{Limit: domain.DefaultPageLimit}).
+		Return([]*domain.Movie{{Id: 1}}, "", nil)
+
+	movies, _, err := service.ListMovies(context.Background(), NewFilter().WithActor(actor), nil, domain.Page{})
+	assert.NoError(t, err)
+	assert.Len(t, movies, 1)
+}
+
+func TestMovieService_ListMovies_ByActorNotExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		ActorExists(gomock.Any(), 2).
+		Return(false, nil)
+
+	movies, _, err := service.ListMovies(context.Background(), NewFilter().WithActor(2), nil, domain.Page{})
+	assert.ErrorIs(t, err, domain.ErrActorNotExists)
+	assert.Nil(t, movies)
+}
+
+func TestMovieService_ListMovies_Collection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	favorites := domain.UserCollection{UserId: 1, Collection: domain.Favorites}
+	repo.
+		EXPECT().
+		ListMovies(gomock.Any(), domain.MovieFilter{Collection: &favorites}, Sorting{NewSortKey(SortByAdded)}, domain.Page{Limit: domain.DefaultPageLimit}).
+		Return([]*domain.Movie{{Id: 1}}, "", nil)
+
+	movies, _, err := service.ListMovies(context.Background(), NewFilter().WithCollection(favorites), nil, domain.Page{})
+	assert.NoError(t, err)
+	assert.Len(t, movies, 1)
+
+	_, _, err = service.ListMovies(context.Background(), NewFilter(), Sorting{NewSortKey(SortByAdded)}, domain.Page{})
+	assert.ErrorIs(t, err, domain.ErrInvalidSort)
+}
+
+func TestMovieService_SimilarMovies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	expected := []*domain.Movie{{Id: 2, Similarity: 1.5}, {Id: 3, Similarity: 0.4}}
+	repo.
+		EXPECT().
+		MovieExists(gomock.Any(), 1).
+		Return(true, nil)
+	repo.
+		EXPECT().
+		SimilarMovies(gomock.Any(), 1, domain.DefaultPageLimit).
+		Return(expected, nil)
+
+	movies, err := service.SimilarMovies(context.Background(), 1, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, movies)
+}
+
+func TestMovieService_SimilarMovies_NotExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		MovieExists(gomock.Any(), 1).
+		Return(false, nil)
+
+	movies, err := service.SimilarMovies(context.Background(), 1, 0)
+	assert.ErrorIs(t, err, domain.ErrMovieNotExists)
+	assert.Nil(t, movies)
+}
+
+func TestMovieService_ExportMovies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	stop := errors.New("client has gone")
+	repo.
+		EXPECT().
+		ExportMovies(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, fn func(*domain.Movie) error) error {
+			for _, m := range testMovies() {
+				if err := fn(m); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).
+		Times(2)
+
+	var exported []int
+	err := service.ExportMovies(context.Background(), func(m *domain.Movie) error {
+		exported = append(exported, m.Id)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, exported, len(testMovies()))
 
-	sortedMovies = SortMovies(movies, SortByTitle)
-	assert.Equal(t, "title1", sortedMovies[0].Title)
-	assert.Equal(t, "title2", sortedMovies[1].Title)
-	assert.Equal(t, "title3", sortedMovies[2].Title)
+	err = service.ExportMovies(context.Background(), func(m *domain.Movie) error { return stop })
+	assert.ErrorIs(t, err, stop)
 }
@@ -0,0 +1,95 @@
+package movie
+
+import (
+	"context"
+	"fmt"
+	"vk-backend/internal/domain"
+)
+
+func (s *movieService) ListRevisions(ctx context.Context, movieId int, page domain.Page) ([]*domain.Revision, string, error) {
+	if err := s.checkMovie(ctx, movieId); err != nil {
+		return nil, "", err
+	}
+
+	revisions, next, err := s.repo.ListRevisions(ctx, domain.AuditMovie, movieId, page.Normalize())
+	if err != nil {
+		return nil, "", fmt.Errorf("movie service can't list revisions: %w", err)
+	}
+
+	return revisions, next, nil
+}
+
+func (s *movieService) GetRevision(ctx context.Context, movieId int, number int) (*domain.Revision, error) {
+	if err := s.checkMovie(ctx, movieId); err != nil {
+		return nil, err
+	}
+	if number <= 0 {
+		return nil, domain.ErrRevisionNotExists
+	}
+
+	revision, err := s.repo.GetRevision(ctx, domain.AuditMovie, movieId, number)
+	if err != nil {
+		return nil, fmt.Errorf("movie service can't get revision: %w", err)
+	}
+
+	return revision, nil
+}
+
+// RestoreRevision updates the movie to the state of the revision, which is saved as a new revision.
+// Actors of the cast, who are gone since then, must be restored first.
+func (s *movieService) RestoreRevision(ctx context.Context, movieId int, number int, version int) (int, error) {
+	revision, err := s.GetRevision(ctx, movieId, number)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, actor := range revision.Movie.Actors {
+		ok, err := s.repo.ActorExists(ctx, actor.Id)
+		if err != nil {
+			return 0, fmt.Errorf("movie service can't check if actor exists: %w", err)
+		}
+		if !ok {
+			return 0, domain.ErrActorNotExists
+		}
+	}
+
+	revision.Movie.Version = version
+	if err := s.UpdateMovie(ctx, revision.Movie); err != nil {
+		return 0, err
+	}
+
+	return revision.Movie.Version, nil
+}
+
+func (s *movieService) DiffRevisions(ctx context.Context, movieId int, from int, to int) ([]domain.FieldChange, error) {
+	before, err := s.GetRevision(ctx, movieId, from)
+	if err != nil {
+		return nil, err
+	}
+	after, err := s.GetRevision(ctx, movieId, to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := before.Diff(after)
+	if err != nil {
+		return nil, fmt.Errorf("movie service can't diff revisions: %w", err)
+	}
+
+	return changes, nil
+}
+
+func (s *movieService) checkMovie(ctx context.Context, movieId int) error {
+	if movieId <= 0 {
+		return domain.ErrMovieNotExists
+	}
+	ok, err := s.repo.MovieExists(ctx, movieId)
+	if err != nil {
+		return fmt.Errorf("movie service can't check if movie exists: %w", err)
+	}
+	if !ok {
+		return domain.ErrMovieNotExists
+	}
+
+	return nil
+}
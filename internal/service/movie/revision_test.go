@@ -0,0 +1,100 @@
+package movie
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	"vk-backend/internal/domain"
+	"vk-backend/mocks"
+)
+
+func TestMovieService_RestoreRevision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	movie := &domain.Movie{
+		Id:          1,
+		Title:       "title",
+		Description: "description",
+		ReleaseDate: time.Date(1999, 3, 31, 0, 0, 0, 0, time.UTC),
+		Actors:      []*domain.CastMember{{Actor: &domain.Actor{Id: 5}, Character: "hero", Billing: 1}},
+		Genres:      []*domain.Genre{{Id: 2}},
+	}
+	repo.EXPECT().MovieExists(gomock.Any(), 1).Return(true, nil).Times(2)
+	repo.EXPECT().GetRevision(gomock.Any(), domain.AuditMovie, 1, 3).Return(&domain.Revision{Number: 3, Movie: movie}, nil)
+	repo.EXPECT().ActorExists(gomock.Any(), 5).Return(true, nil)
+	repo.EXPECT().UpdateMovie(gomock.Any(), movie).DoAndReturn(func(_ context.Context, m *domain.Movie) error {
+		// the revision is restored over the version the client has seen
+		assert.Equal(t, 7, m.Version)
+		m.Version++
+		return nil
+	})
+
+	version, err := service.RestoreRevision(context.Background(), 1, 3, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 8, version)
+}
+
+func TestMovieService_RestoreRevision_ActorGone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	movie := &domain.Movie{Id: 1, Actors: []*domain.CastMember{{Actor: &domain.Actor{Id: 5}}}}
+	repo.EXPECT().MovieExists(gomock.Any(), 1).Return(true, nil)
+	repo.EXPECT().GetRevision(gomock.Any(), domain.AuditMovie, 1, 3).Return(&domain.Revision{Number: 3, Movie: movie}, nil)
+	repo.EXPECT().ActorExists(gomock.Any(), 5).Return(false, nil)
+
+	_, err := service.RestoreRevision(context.Background(), 1, 3, 7)
+	assert.ErrorIs(t, err, domain.ErrActorNotExists)
+}
+
+func TestMovieService_GetRevision_NotExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	repo.EXPECT().MovieExists(gomock.Any(), 1).Return(true, nil).Times(2)
+	repo.EXPECT().GetRevision(gomock.Any(), domain.AuditMovie, 1, 7).Return(nil, domain.ErrRevisionNotExists)
+
+	_, err := service.GetRevision(context.Background(), 1, 7)
+	assert.ErrorIs(t, err, domain.ErrRevisionNotExists)
+
+	_, err = service.GetRevision(context.Background(), 1, 0)
+	assert.ErrorIs(t, err, domain.ErrRevisionNotExists)
+}
+
+func TestMovieService_DiffRevisions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	repo.EXPECT().MovieExists(gomock.Any(), 1).Return(true, nil).Times(2)
+	repo.EXPECT().GetRevision(gomock.Any(), domain.AuditMovie, 1, 1).Return(&domain.Revision{
+		Number: 1,
+		Data:   json.RawMessage(`{"cast": [], "title": "old", "rating": 5.0, "genres": [1]}`),
+	}, nil)
+	repo.EXPECT().GetRevision(gomock.Any(), domain.AuditMovie, 1, 2).Return(&domain.Revision{
+		Number: 2,
+		Data:   json.RawMessage(`{"cast": [], "title": "new", "rating": 5.0, "genres": [1, 2]}`),
+	}, nil)
+
+	changes, err := service.DiffRevisions(context.Background(), 1, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.FieldChange{
+		{Field: "genres", Before: json.RawMessage(`[1]`), After: json.RawMessage(`[1, 2]`)},
+		{Field: "title", Before: json.RawMessage(`"old"`), After: json.RawMessage(`"new"`)},
+	}, changes)
+}
@@ -1,34 +1,80 @@
 package movie
 
-import (
-	"sort"
-	"vk-backend/internal/domain"
-)
+import "vk-backend/internal/domain"
 
-type SortBy int
+type SortBy = domain.MovieSortField
 
 const (
-	SortByRating SortBy = iota
-	SortByReleaseDate
-	SortByTitle
+	SortByRating      = domain.MovieSortByRating
+	SortByReleaseDate = domain.MovieSortByReleaseDate
+	SortByTitle       = domain.MovieSortByTitle
+	SortByRelevance   = domain.MovieSortByRelevance
+	SortByAdded       = domain.MovieSortByAdded
 
 	DefaultSort = SortByRating
+	// SortByDefault stands for the field movies are sorted by when no sorting is requested,
+	// so a sorting of only this key gives just the direction
+	SortByDefault SortBy = -1
 )
 
-func SortMovies(movies []*domain.Movie, sorting SortBy) []*domain.Movie {
-	sortFunc := func(i, j int) bool {
-		switch sorting {
-		case SortByRating:
-			return movies[i].Rating > movies[j].Rating
-		case SortByReleaseDate:
-			return movies[j].ReleaseDate.Before(movies[i].ReleaseDate)
-		case SortByTitle:
-			return movies[i].Title < movies[j].Title
-		default:
-			return movies[i].Rating < movies[j].Rating
+type Sorting = domain.MovieSort
+
+// defaultDesc tells in which direction a field is sorted unless the direction is given explicitly
+var defaultDesc = map[SortBy]bool{
+	SortByRating:      true,
+	SortByReleaseDate: true,
+	SortByTitle:       false,
+	SortByRelevance:   true,
+	SortByAdded:       true,
+}
+
+// NewSortKey sorts by the field in its default direction: the best rated, the newest, the most relevant
+// and the most recently added movies go first, titles go in alphabetical order
+func NewSortKey(field SortBy) domain.MovieSortKey {
+	return domain.MovieSortKey{Field: field, Desc: defaultDesc[field]}
+}
+
+// defaultSorting is used when no sorting is requested, search results are ordered by relevance,
+// collections by the time movies were added and filmographies by release date
+func defaultSorting(filter *Filter) Sorting {
+	switch {
+	case filter != nil && filter.query != nil:
+		return Sorting{NewSortKey(SortByRelevance)}
+	case filter != nil && filter.collection != nil:
+		return Sorting{NewSortKey(SortByAdded)}
+	case filter != nil && filter.actor != nil:
+		return Sorting{NewSortKey(SortByReleaseDate)}
+	}
+	return Sorting{NewSortKey(DefaultSort)}
+}
+
+// resolveSorting uses the default sorting when none is requested and puts the default field in place of SortByDefault
+func resolveSorting(sorting Sorting, filter *Filter) Sorting {
+	switch {
+	case len(sorting) == 0:
+		return defaultSorting(filter)
+	case len(sorting) == 1 && sorting[0].Field == SortByDefault:
+		key := defaultSorting(filter)[0]
+		key.Desc = sorting[0].Desc
+		return Sorting{key}
+	}
+	return sorting
+}
+
+func validateSorting(sorting Sorting, filter *Filter) error {
+	seen := make(map[SortBy]bool, len(sorting))
+	for _, key := range sorting {
+		if _, ok := defaultDesc[key.Field]; !ok || seen[key.Field] {
+			return domain.ErrInvalidSort
+		}
+		if key.Field == SortByRelevance && (filter == nil || filter.query == nil) {
+			return domain.ErrInvalidSort
+		}
+		if key.Field == SortByAdded && (filter == nil || filter.collection == nil) {
+			return domain.ErrInvalidSort
 		}
+		seen[key.Field] = true
 	}
-	sort.Slice(movies, sortFunc)
 
-	return movies
+	return nil
 }
@@ -0,0 +1,46 @@
+package movie
+
+import (
+	"context"
+	"fmt"
+	"vk-backend/internal/domain"
+)
+
+func (s *movieService) ListDeletedMovies(ctx context.Context, page domain.Page) ([]*domain.Movie, string, error) {
+	movies, next, err := s.repo.ListDeletedMovies(ctx, page.Normalize())
+	if err != nil {
+		return nil, "", fmt.Errorf("movie service can't list deleted movies: %w", err)
+	}
+
+	return movies, next, nil
+}
+
+func (s *movieService) RestoreMovie(ctx context.Context, id int) error {
+	if id <= 0 {
+		return domain.ErrNotInTrash
+	}
+	ok, err := s.repo.RestoreMovie(ctx, id)
+	if err != nil {
+		return fmt.Errorf("movie service can't restore movie: %w", err)
+	}
+	if !ok {
+		return domain.ErrNotInTrash
+	}
+
+	return nil
+}
+
+func (s *movieService) PurgeMovie(ctx context.Context, id int) error {
+	if id <= 0 {
+		return domain.ErrNotInTrash
+	}
+	ok, err := s.repo.PurgeMovie(ctx, id)
+	if err != nil {
+		return fmt.Errorf("movie service can't purge movie: %w", err)
+	}
+	if !ok {
+		return domain.ErrNotInTrash
+	}
+
+	return nil
+}
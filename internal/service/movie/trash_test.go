@@ -0,0 +1,60 @@
+package movie
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	"vk-backend/internal/domain"
+	"vk-backend/mocks"
+)
+
+func TestMovieService_ListDeletedMovies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	deletedAt := time.Now()
+	repo.
+		EXPECT().
+		ListDeletedMovies(gomock.Any(), domain.Page{Limit: domain.DefaultPageLimit}).
+		Return([]*domain.Movie{{Id: 1, Title: "title", DeletedAt: &deletedAt}}, "next", nil)
+
+	actors, next, err := service.ListDeletedMovies(context.Background(), domain.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, "next", next)
+	assert.Equal(t, []*domain.Movie{{Id: 1, Title: "title", DeletedAt: &deletedAt}}, actors)
+}
+
+func TestMovieService_RestoreMovie(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	repo.EXPECT().RestoreMovie(gomock.Any(), 1).Return(true, nil)
+	assert.NoError(t, service.RestoreMovie(context.Background(), 1))
+
+	repo.EXPECT().RestoreMovie(gomock.Any(), 2).Return(false, nil)
+	assert.ErrorIs(t, service.RestoreMovie(context.Background(), 2), domain.ErrNotInTrash)
+
+	assert.ErrorIs(t, service.RestoreMovie(context.Background(), 0), domain.ErrNotInTrash)
+}
+
+func TestMovieService_PurgeMovie(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMovieRepository(ctrl)
+	service := NewService(repo)
+
+	repo.EXPECT().PurgeMovie(gomock.Any(), 1).Return(true, nil)
+	assert.NoError(t, service.PurgeMovie(context.Background(), 1))
+
+	repo.EXPECT().PurgeMovie(gomock.Any(), 2).Return(false, nil)
+	assert.ErrorIs(t, service.PurgeMovie(context.Background(), 2), domain.ErrNotInTrash)
+}
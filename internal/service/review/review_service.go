@@ -0,0 +1,114 @@
+package review
+
+import (
+	"context"
+	"fmt"
+	"vk-backend/internal/domain"
+	"vk-backend/internal/repository"
+)
+
+const maxReviewLength = 2000
+
+type ReviewService interface {
+	// SaveReview creates the review of the user or overwrites the one the user left before
+	SaveReview(ctx context.Context, movieId int, userId int, rating int, text string) (*domain.Review, error)
+	GetReview(ctx context.Context, movieId int, userId int) (*domain.Review, error)
+	DeleteReview(ctx context.Context, movieId int, userId int) error
+
+	ListReviews(ctx context.Context, movieId int, page domain.Page) ([]*domain.Review, string, error)
+}
+
+type reviewService struct {
+	repo repository.ReviewRepository
+}
+
+func NewService(repo repository.ReviewRepository) ReviewService {
+	return &reviewService{
+		repo: repo,
+	}
+}
+
+func (s *reviewService) SaveReview(ctx context.Context, movieId int, userId int, rating int, text string) (*domain.Review, error) {
+	if err := validateReview(rating, text); err != nil {
+		return nil, err
+	}
+	if err := s.checkMovie(ctx, movieId); err != nil {
+		return nil, err
+	}
+
+	review, err := s.repo.SaveReview(ctx, movieId, userId, rating, text)
+	if err != nil {
+		return nil, fmt.Errorf("review service can't save review: %w", err)
+	}
+
+	return review, nil
+}
+
+func (s *reviewService) GetReview(ctx context.Context, movieId int, userId int) (*domain.Review, error) {
+	if err := s.checkMovie(ctx, movieId); err != nil {
+		return nil, err
+	}
+
+	review, err := s.repo.GetReview(ctx, movieId, userId)
+	if err != nil {
+		return nil, fmt.Errorf("review service can't get review: %w", err)
+	}
+
+	return review, nil
+}
+
+func (s *reviewService) DeleteReview(ctx context.Context, movieId int, userId int) error {
+	if err := s.checkMovie(ctx, movieId); err != nil {
+		return err
+	}
+	if _, err := s.repo.GetReview(ctx, movieId, userId); err != nil {
+		return fmt.Errorf("review service can't get review: %w", err)
+	}
+
+	err := s.repo.DeleteReview(ctx, movieId, userId)
+	if err != nil {
+		return fmt.Errorf("review service can't delete review: %w", err)
+	}
+
+	return nil
+}
+
+// ListReviews returns a page of reviews of the movie, the newest go first
+func (s *reviewService) ListReviews(ctx context.Context, movieId int, page domain.Page) ([]*domain.Review, string, error) {
+	if err := s.checkMovie(ctx, movieId); err != nil {
+		return nil, "", err
+	}
+
+	reviews, next, err := s.repo.ListReviews(ctx, movieId, page.Normalize())
+	if err != nil {
+		return nil, "", fmt.Errorf("review service can't list reviews: %w", err)
+	}
+
+	return reviews, next, nil
+}
+
+func (s *reviewService) checkMovie(ctx context.Context, movieId int) error {
+	if movieId <= 0 {
+		return domain.ErrMovieNotExists
+	}
+	ok, err := s.repo.MovieExists(ctx, movieId)
+	if err != nil {
+		return fmt.Errorf("review service can't check if movie exists: %w", err)
+	}
+	if !ok {
+		return domain.ErrMovieNotExists
+	}
+
+	return nil
+}
+
+func validateReview(rating int, text string) error {
+	if rating < 0 || rating > 10 {
+		return domain.ErrInvalidRating
+	}
+	if len(text) > maxReviewLength {
+		return domain.ErrTooLongReview
+	}
+
+	return nil
+}
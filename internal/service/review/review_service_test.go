@@ -0,0 +1,113 @@
+package review
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"strings"
+	"testing"
+	"vk-backend/internal/domain"
+	"vk-backend/mocks"
+)
+
+func TestReviewService_SaveReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockReviewRepository(ctrl)
+	service := NewService(repo)
+
+	expected := &domain.Review{Id: 1, MovieId: 1, UserId: 2, Rating: 8, Text: "good"}
+	repo.
+		EXPECT().
+		MovieExists(gomock.Any(), 1).
+		Return(true, nil)
+	repo.
+		EXPECT().
+		SaveReview(gomock.Any(), 1, 2, 8, "good").
+		Return(expected, nil)
+
+	review, err := service.SaveReview(context.Background(), 1, 2, 8, "good")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, review)
+}
+
+func TestReviewService_SaveReview_InvalidData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockReviewRepository(ctrl)
+	service := NewService(repo)
+
+	review, err := service.SaveReview(context.Background(), 1, 2, 11, "")
+	assert.ErrorIs(t, err, domain.ErrInvalidRating)
+	assert.Nil(t, review)
+
+	review, err = service.SaveReview(context.Background(), 1, 2, -1, "")
+	assert.ErrorIs(t, err, domain.ErrInvalidRating)
+	assert.Nil(t, review)
+
+	review, err = service.SaveReview(context.Background(), 1, 2, 5, strings.Repeat("a", 2001))
+	assert.ErrorIs(t, err, domain.ErrTooLongReview)
+	assert.Nil(t, review)
+}
+
+func TestReviewService_SaveReview_MovieNotExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockReviewRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		MovieExists(gomock.Any(), 1).
+		Return(false, nil)
+
+	review, err := service.SaveReview(context.Background(), 1, 2, 8, "")
+	assert.ErrorIs(t, err, domain.ErrMovieNotExists)
+	assert.Nil(t, review)
+}
+
+func TestReviewService_DeleteReview_NotExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockReviewRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		MovieExists(gomock.Any(), 1).
+		Return(true, nil)
+	repo.
+		EXPECT().
+		GetReview(gomock.Any(), 1, 2).
+		Return(nil, domain.ErrReviewNotExists)
+
+	err := service.DeleteReview(context.Background(), 1, 2)
+	assert.ErrorIs(t, err, domain.ErrReviewNotExists)
+}
+
+func TestReviewService_ListReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockReviewRepository(ctrl)
+	service := NewService(repo)
+
+	expected := []*domain.Review{{Id: 2, MovieId: 1}, {Id: 1, MovieId: 1}}
+	repo.
+		EXPECT().
+		MovieExists(gomock.Any(), 1).
+		Return(true, nil)
+	repo.
+		EXPECT().
+		ListReviews(gomock.Any(), 1, domain.Page{Limit: domain.DefaultPageLimit}).
+		Return(expected, "next", nil)
+
+	reviews, next, err := service.ListReviews(context.Background(), 1, domain.Page{})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, reviews)
+	assert.Equal(t, "next", next)
+}
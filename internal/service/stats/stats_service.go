@@ -0,0 +1,118 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"vk-backend/internal/domain"
+	"vk-backend/internal/repository"
+)
+
+const (
+	DefaultRatingBuckets = 10
+	MaxRatingBuckets     = 100
+
+	ageBucketWidth = 10
+)
+
+// StatsService aggregates the catalog for dashboards, all the numbers are computed by the database
+type StatsService interface {
+	MoviesByYear(ctx context.Context) ([]*domain.YearStats, error)
+	// RatingHistogram splits scores into buckets of equal width, zero buckets means DefaultRatingBuckets
+	RatingHistogram(ctx context.Context, buckets int) ([]*domain.RatingBucket, error)
+	// ProlificActors returns actors who played in the most movies, zero limit means the default page size
+	ProlificActors(ctx context.Context, limit int) ([]*domain.ProlificActor, error)
+	// ActorAges describes how old actors were at the release of their movies, nil actor means all the actors
+	ActorAges(ctx context.Context, actorId *int) (*domain.AgeStats, error)
+}
+
+type statsService struct {
+	repo repository.StatsRepository
+}
+
+func NewService(repo repository.StatsRepository) StatsService {
+	return &statsService{
+		repo: repo,
+	}
+}
+
+func (s *statsService) MoviesByYear(ctx context.Context) ([]*domain.YearStats, error) {
+	years, err := s.repo.MoviesByYear(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("stats service can't get movies by year: %w", err)
+	}
+
+	return years, nil
+}
+
+func (s *statsService) RatingHistogram(ctx context.Context, buckets int) ([]*domain.RatingBucket, error) {
+	if buckets == 0 {
+		buckets = DefaultRatingBuckets
+	}
+	if buckets < 0 || buckets > MaxRatingBuckets {
+		return nil, domain.ErrInvalidBuckets
+	}
+
+	histogram, err := s.repo.RatingHistogram(ctx, buckets)
+	if err != nil {
+		return nil, fmt.Errorf("stats service can't get rating histogram: %w", err)
+	}
+
+	return histogram, nil
+}
+
+func (s *statsService) ProlificActors(ctx context.Context, limit int) ([]*domain.ProlificActor, error) {
+	actors, err := s.repo.ProlificActors(ctx, domain.Page{Limit: limit}.Normalize().Limit)
+	if err != nil {
+		return nil, fmt.Errorf("stats service can't get prolific actors: %w", err)
+	}
+
+	return actors, nil
+}
+
+func (s *statsService) ActorAges(ctx context.Context, actorId *int) (*domain.AgeStats, error) {
+	if actorId != nil {
+		if *actorId <= 0 {
+			return nil, domain.ErrActorNotExists
+		}
+		ok, err := s.repo.ActorExists(ctx, *actorId)
+		if err != nil {
+			return nil, fmt.Errorf("stats service can't check if actor exists: %w", err)
+		}
+		if !ok {
+			return nil, domain.ErrActorNotExists
+		}
+	}
+
+	ages, err := s.repo.ActorAges(ctx, actorId)
+	if err != nil {
+		return nil, fmt.Errorf("stats service can't get actor ages: %w", err)
+	}
+
+	return summarizeAges(ages), nil
+}
+
+// summarizeAges groups ages sorted in ascending order into decades
+func summarizeAges(ages []domain.AgeCount) *domain.AgeStats {
+	stats := &domain.AgeStats{Buckets: []domain.AgeBucket{}}
+	if len(ages) == 0 {
+		return stats
+	}
+
+	sum := 0
+	for _, age := range ages {
+		stats.Roles += age.Roles
+		sum += age.Age * age.Roles
+
+		from := age.Age / ageBucketWidth * ageBucketWidth
+		if n := len(stats.Buckets); n > 0 && stats.Buckets[n-1].From == from {
+			stats.Buckets[n-1].Roles += age.Roles
+			continue
+		}
+		stats.Buckets = append(stats.Buckets, domain.AgeBucket{From: from, To: from + ageBucketWidth, Roles: age.Roles})
+	}
+	stats.AverageAge = float64(sum) / float64(stats.Roles)
+	stats.Youngest = ages[0].Age
+	stats.Oldest = ages[len(ages)-1].Age
+
+	return stats
+}
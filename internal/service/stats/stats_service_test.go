@@ -0,0 +1,117 @@
+package stats
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"vk-backend/internal/domain"
+	"vk-backend/mocks"
+)
+
+func TestStatsService_RatingHistogram(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockStatsRepository(ctrl)
+	service := NewService(repo)
+
+	expected := []*domain.RatingBucket{{From: 0, To: 5, MovieCount: 1}, {From: 5, To: 10, MovieCount: 3}}
+	repo.
+		EXPECT().
+		RatingHistogram(gomock.Any(), 2).
+		Return(expected, nil)
+	repo.
+		EXPECT().
+		RatingHistogram(gomock.Any(), DefaultRatingBuckets).
+		Return(nil, nil)
+
+	histogram, err := service.RatingHistogram(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, histogram)
+
+	_, err = service.RatingHistogram(context.Background(), 0)
+	assert.NoError(t, err)
+
+	_, err = service.RatingHistogram(context.Background(), MaxRatingBuckets+1)
+	assert.ErrorIs(t, err, domain.ErrInvalidBuckets)
+}
+
+func TestStatsService_ProlificActors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockStatsRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		ProlificActors(gomock.Any(), domain.DefaultPageLimit).
+		Return(nil, nil)
+	repo.
+		EXPECT().
+		ProlificActors(gomock.Any(), domain.MaxPageLimit).
+		Return(nil, nil)
+
+	_, err := service.ProlificActors(context.Background(), 0)
+	assert.NoError(t, err)
+
+	_, err = service.ProlificActors(context.Background(), domain.MaxPageLimit*2)
+	assert.NoError(t, err)
+}
+
+func TestStatsService_ActorAges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockStatsRepository(ctrl)
+	service := NewService(repo)
+
+	repo.
+		EXPECT().
+		ActorAges(gomock.Any(), nil).
+		Return([]domain.AgeCount{{Age: 18, Roles: 1}, {Age: 25, Roles: 2}, {Age: 29, Roles: 1}, {Age: 41, Roles: 1}}, nil)
+
+	ages, err := service.ActorAges(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.AgeStats{
+		Roles:      5,
+		AverageAge: 27.6,
+		Youngest:   18,
+		Oldest:     41,
+		Buckets: []domain.AgeBucket{
+			{From: 10, To: 20, Roles: 1},
+			{From: 20, To: 30, Roles: 3},
+			{From: 40, To: 50, Roles: 1},
+		},
+	}, ages)
+}
+
+func TestStatsService_ActorAges_Actor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockStatsRepository(ctrl)
+	service := NewService(repo)
+
+	actorId, missingId := 1, 2
+	repo.
+		EXPECT().
+		ActorExists(gomock.Any(), actorId).
+		Return(true, nil)
+	repo.
+		EXPECT().
+		ActorAges(gomock.Any(), &actorId).
+		Return(nil, nil)
+	repo.
+		EXPECT().
+		ActorExists(gomock.Any(), missingId).
+		Return(false, nil)
+
+	ages, err := service.ActorAges(context.Background(), &actorId)
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.AgeStats{Buckets: []domain.AgeBucket{}}, ages)
+
+	_, err = service.ActorAges(context.Background(), &missingId)
+	assert.ErrorIs(t, err, domain.ErrActorNotExists)
+}
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS movie_actors_actor_id_idx;
+DROP INDEX IF EXISTS movie_actors_movie_id_idx;
+DROP INDEX IF EXISTS movies_title_idx;
+DROP INDEX IF EXISTS movies_release_date_idx;
+DROP INDEX IF EXISTS movies_rating_idx;
+DROP INDEX IF EXISTS actors_name_trgm_idx;
+DROP INDEX IF EXISTS movies_title_trgm_idx;
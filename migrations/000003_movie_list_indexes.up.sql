@@ -0,0 +1,13 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- substring search by movie title or actor name (ILIKE '%...%')
+CREATE INDEX IF NOT EXISTS movies_title_trgm_idx ON movies USING GIN (title gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS actors_name_trgm_idx ON actors USING GIN (name gin_trgm_ops);
+
+-- range filters and every supported sort order
+CREATE INDEX IF NOT EXISTS movies_rating_idx ON movies (rating DESC, id);
+CREATE INDEX IF NOT EXISTS movies_release_date_idx ON movies (release_date DESC, id);
+CREATE INDEX IF NOT EXISTS movies_title_idx ON movies ((title COLLATE "C"), id);
+
+CREATE INDEX IF NOT EXISTS movie_actors_movie_id_idx ON movie_actors (movie_id);
+CREATE INDEX IF NOT EXISTS movie_actors_actor_id_idx ON movie_actors (actor_id);
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS movies_search_vector_idx;
+
+DROP TRIGGER IF EXISTS actors_search_vector ON actors;
+DROP TRIGGER IF EXISTS movie_actors_search_vector ON movie_actors;
+DROP TRIGGER IF EXISTS movies_search_vector ON movies;
+
+DROP FUNCTION IF EXISTS actors_refresh_search_vector();
+DROP FUNCTION IF EXISTS movie_actors_refresh_search_vector();
+DROP FUNCTION IF EXISTS movies_refresh_search_vector();
+DROP FUNCTION IF EXISTS movie_search_vector(int, text, text);
+
+ALTER TABLE movies
+    DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,75 @@
+ALTER TABLE movies
+    ADD COLUMN IF NOT EXISTS search_vector tsvector NOT NULL DEFAULT ''::tsvector;
+
+-- 'russian' configuration stems cyrillic words with the russian stemmer and latin words with the english one,
+-- so it suits the catalog mixing both languages
+CREATE OR REPLACE FUNCTION movie_search_vector(movie_id int, title text, description text) RETURNS tsvector
+    LANGUAGE sql
+    STABLE AS
+$$
+SELECT setweight(to_tsvector('russian', $2), 'A') ||
+       setweight(to_tsvector('russian', $3), 'B') ||
+       setweight(to_tsvector('russian', coalesce((SELECT string_agg(a.name, ' ')
+                                                  FROM movie_actors ma
+                                                           JOIN actors a ON a.id = ma.actor_id
+                                                  WHERE ma.movie_id = $1), '')), 'C')
+$$;
+
+CREATE OR REPLACE FUNCTION movies_refresh_search_vector() RETURNS trigger
+    LANGUAGE plpgsql AS
+$$
+BEGIN
+    NEW.search_vector := movie_search_vector(NEW.id, NEW.title, NEW.description);
+    RETURN NEW;
+END
+$$;
+
+CREATE TRIGGER movies_search_vector
+    BEFORE INSERT OR UPDATE OF title, description
+    ON movies
+    FOR EACH ROW
+EXECUTE FUNCTION movies_refresh_search_vector();
+
+CREATE OR REPLACE FUNCTION movie_actors_refresh_search_vector() RETURNS trigger
+    LANGUAGE plpgsql AS
+$$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        UPDATE movies SET search_vector = movie_search_vector(id, title, description) WHERE id = OLD.movie_id;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        UPDATE movies SET search_vector = movie_search_vector(id, title, description) WHERE id = NEW.movie_id;
+    END IF;
+    RETURN NULL;
+END
+$$;
+
+CREATE TRIGGER movie_actors_search_vector
+    AFTER INSERT OR UPDATE OR DELETE
+    ON movie_actors
+    FOR EACH ROW
+EXECUTE FUNCTION movie_actors_refresh_search_vector();
+
+CREATE OR REPLACE FUNCTION actors_refresh_search_vector() RETURNS trigger
+    LANGUAGE plpgsql AS
+$$
+BEGIN
+    UPDATE movies m
+    SET search_vector = movie_search_vector(m.id, m.title, m.description)
+    FROM movie_actors ma
+    WHERE ma.movie_id = m.id
+      AND ma.actor_id = NEW.id;
+    RETURN NULL;
+END
+$$;
+
+CREATE TRIGGER actors_search_vector
+    AFTER UPDATE OF name
+    ON actors
+    FOR EACH ROW
+EXECUTE FUNCTION actors_refresh_search_vector();
+
+UPDATE movies
+SET search_vector = movie_search_vector(id, title, description);
+
+CREATE INDEX IF NOT EXISTS movies_search_vector_idx ON movies USING GIN (search_vector);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS movie_genres;
+DROP TABLE IF EXISTS genres;
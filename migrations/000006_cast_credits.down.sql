@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS movie_actors_movie_id_billing_idx;
+CREATE INDEX IF NOT EXISTS movie_actors_movie_id_idx ON movie_actors (movie_id);
+
+ALTER TABLE movie_actors
+    DROP COLUMN IF EXISTS billing,
+    DROP COLUMN IF EXISTS character_name;
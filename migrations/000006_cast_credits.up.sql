@@ -0,0 +1,15 @@
+ALTER TABLE movie_actors
+    ADD COLUMN IF NOT EXISTS character_name VARCHAR(150) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS billing        INT CHECK (billing > 0);
+
+-- existing casts are billed in the order actors were added
+UPDATE movie_actors ma
+SET billing = credits.position
+FROM (SELECT id, row_number() OVER (PARTITION BY movie_id ORDER BY id) AS position FROM movie_actors) credits
+WHERE credits.id = ma.id;
+
+ALTER TABLE movie_actors
+    ALTER COLUMN billing SET NOT NULL;
+
+DROP INDEX IF EXISTS movie_actors_movie_id_idx;
+CREATE INDEX IF NOT EXISTS movie_actors_movie_id_billing_idx ON movie_actors (movie_id, billing, id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS actors_birth_date_idx;
+DROP INDEX IF EXISTS actors_name_idx;
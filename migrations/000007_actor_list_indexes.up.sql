@@ -0,0 +1,3 @@
+-- sort orders of the actor catalog, names are compared bytewise
+CREATE INDEX IF NOT EXISTS actors_name_idx ON actors ((name COLLATE "C"), id);
+CREATE INDEX IF NOT EXISTS actors_birth_date_idx ON actors (birth_date, id);
@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS reviews_movie_score ON reviews;
+DROP FUNCTION IF EXISTS reviews_refresh_movie_score();
+
+DROP INDEX IF EXISTS movies_score_idx;
+ALTER TABLE movies
+    DROP COLUMN IF EXISTS score,
+    DROP COLUMN IF EXISTS review_rating_sum,
+    DROP COLUMN IF EXISTS review_count;
+
+DROP TABLE IF EXISTS reviews;
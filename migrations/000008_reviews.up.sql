@@ -0,0 +1,49 @@
+CREATE TABLE IF NOT EXISTS reviews
+(
+    id         SERIAL PRIMARY KEY,
+    movie_id   INT           NOT NULL REFERENCES movies (id) ON DELETE CASCADE,
+    user_id    INT           NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    rating     SMALLINT      NOT NULL CHECK (rating BETWEEN 0 AND 10),
+    text       VARCHAR(2000) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    UNIQUE (movie_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS reviews_movie_id_idx ON reviews (movie_id, id DESC);
+
+-- user ratings are aggregated on the movie, the score falls back to the rating set by admins until the movie is rated
+ALTER TABLE movies
+    ADD COLUMN IF NOT EXISTS review_count      INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS review_rating_sum INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS score             NUMERIC(3, 1) GENERATED ALWAYS AS (
+        CASE WHEN review_count > 0 THEN round(review_rating_sum::numeric / review_count, 1) ELSE rating END
+        ) STORED;
+
+CREATE INDEX IF NOT EXISTS movies_score_idx ON movies (score DESC, id);
+
+CREATE OR REPLACE FUNCTION reviews_refresh_movie_score() RETURNS trigger
+    LANGUAGE plpgsql AS
+$$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        UPDATE movies
+        SET review_count      = review_count - 1,
+            review_rating_sum = review_rating_sum - OLD.rating
+        WHERE id = OLD.movie_id;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        UPDATE movies
+        SET review_count      = review_count + 1,
+            review_rating_sum = review_rating_sum + NEW.rating
+        WHERE id = NEW.movie_id;
+    END IF;
+    RETURN NULL;
+END
+$$;
+
+CREATE TRIGGER reviews_movie_score
+    AFTER INSERT OR UPDATE OF rating, movie_id OR DELETE
+    ON reviews
+    FOR EACH ROW
+EXECUTE FUNCTION reviews_refresh_movie_score();
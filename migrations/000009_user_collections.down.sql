@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS user_movies;
+DROP TYPE IF EXISTS collection;
@@ -0,0 +1,12 @@
+CREATE TYPE collection AS ENUM ('watchlist', 'favorites');
+
+CREATE TABLE IF NOT EXISTS user_movies
+(
+    user_id    INT         NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    collection collection  NOT NULL,
+    movie_id   INT         NOT NULL REFERENCES movies (id) ON DELETE CASCADE,
+    added_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, collection, movie_id)
+);
+
+CREATE INDEX IF NOT EXISTS user_movies_added_at_idx ON user_movies (user_id, collection, added_at DESC, movie_id);
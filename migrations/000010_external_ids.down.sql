@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS import_checkpoints;
+
+DROP INDEX IF EXISTS movies_external_id_idx;
+DROP INDEX IF EXISTS actors_external_id_idx;
+
+ALTER TABLE movies
+    DROP COLUMN IF EXISTS external_id;
+ALTER TABLE actors
+    DROP COLUMN IF EXISTS external_id;
@@ -0,0 +1,16 @@
+-- records imported from external datasets (IMDb nconst/tconst) are matched by these ids on every import
+ALTER TABLE actors
+    ADD COLUMN IF NOT EXISTS external_id VARCHAR(16);
+ALTER TABLE movies
+    ADD COLUMN IF NOT EXISTS external_id VARCHAR(16);
+
+CREATE UNIQUE INDEX IF NOT EXISTS actors_external_id_idx ON actors (external_id);
+CREATE UNIQUE INDEX IF NOT EXISTS movies_external_id_idx ON movies (external_id);
+
+-- how many lines of each dataset file are imported, so an interrupted import resumes where it stopped
+CREATE TABLE IF NOT EXISTS import_checkpoints
+(
+    source     TEXT PRIMARY KEY,
+    line       BIGINT      NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
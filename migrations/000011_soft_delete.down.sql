@@ -0,0 +1,30 @@
+DROP TRIGGER IF EXISTS actors_search_vector ON actors;
+CREATE TRIGGER actors_search_vector
+    AFTER UPDATE OF name
+    ON actors
+    FOR EACH ROW
+EXECUTE FUNCTION actors_refresh_search_vector();
+
+CREATE OR REPLACE FUNCTION movie_search_vector(movie_id int, title text, description text) RETURNS tsvector
+    LANGUAGE sql
+    STABLE AS
+$$
+SELECT setweight(to_tsvector('russian', $2), 'A') ||
+       setweight(to_tsvector('russian', $3), 'B') ||
+       setweight(to_tsvector('russian', coalesce((SELECT string_agg(a.name, ' ')
+                                                  FROM movie_actors ma
+                                                           JOIN actors a ON a.id = ma.actor_id
+                                                  WHERE ma.movie_id = $1), '')), 'C')
+$$;
+
+DROP INDEX IF EXISTS actors_deleted_at_idx;
+DROP INDEX IF EXISTS movies_deleted_at_idx;
+
+-- rows in the trash are deleted for good, the same way they were deleted before
+DELETE FROM movies WHERE deleted_at IS NOT NULL;
+DELETE FROM actors WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE actors
+    DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE movies
+    DROP COLUMN IF EXISTS deleted_at;
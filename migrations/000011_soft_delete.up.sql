@@ -0,0 +1,29 @@
+-- deleted movies and actors stay in the trash with their casts until they are restored or purged
+ALTER TABLE movies
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE actors
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS movies_deleted_at_idx ON movies (deleted_at DESC, id) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS actors_deleted_at_idx ON actors (deleted_at DESC, id) WHERE deleted_at IS NOT NULL;
+
+-- names of deleted actors aren't searchable
+CREATE OR REPLACE FUNCTION movie_search_vector(movie_id int, title text, description text) RETURNS tsvector
+    LANGUAGE sql
+    STABLE AS
+$$
+SELECT setweight(to_tsvector('russian', $2), 'A') ||
+       setweight(to_tsvector('russian', $3), 'B') ||
+       setweight(to_tsvector('russian', coalesce((SELECT string_agg(a.name, ' ')
+                                                  FROM movie_actors ma
+                                                           JOIN actors a ON a.id = ma.actor_id
+                                                  WHERE ma.movie_id = $1
+                                                    AND a.deleted_at IS NULL), '')), 'C')
+$$;
+
+DROP TRIGGER IF EXISTS actors_search_vector ON actors;
+CREATE TRIGGER actors_search_vector
+    AFTER UPDATE OF name, deleted_at
+    ON actors
+    FOR EACH ROW
+EXECUTE FUNCTION actors_refresh_search_vector();
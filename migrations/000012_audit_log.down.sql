@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS audit_log;
+
+DROP TYPE IF EXISTS audit_entity;
+DROP TYPE IF EXISTS audit_action;
@@ -0,0 +1,19 @@
+CREATE TYPE audit_action AS ENUM ('create', 'update', 'delete', 'restore', 'purge');
+CREATE TYPE audit_entity AS ENUM ('actor', 'movie', 'cast');
+
+-- snapshots of the entity before and after every admin change, written in the transaction of the change
+CREATE TABLE IF NOT EXISTS audit_log
+(
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    INT          REFERENCES users (id) ON DELETE SET NULL,
+    action     audit_action NOT NULL,
+    entity     audit_entity NOT NULL,
+    entity_id  INT          NOT NULL,
+    before     JSONB,
+    after      JSONB,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_created_at_idx ON audit_log (created_at);
+CREATE INDEX IF NOT EXISTS audit_log_user_idx ON audit_log (user_id, id DESC);
+CREATE INDEX IF NOT EXISTS audit_log_entity_idx ON audit_log (entity, entity_id, id DESC);
@@ -0,0 +1,12 @@
+-- every update of a movie or an actor saves its new state as the next revision,
+-- the first update also saves the state before it as revision 1
+CREATE TABLE IF NOT EXISTS revisions
+(
+    entity     audit_entity NOT NULL CHECK (entity IN ('actor', 'movie')),
+    entity_id  INT          NOT NULL,
+    number     INT          NOT NULL,
+    data       JSONB        NOT NULL,
+    user_id    INT          REFERENCES users (id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    PRIMARY KEY (entity, entity_id, number)
+);
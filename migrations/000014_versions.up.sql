@@ -0,0 +1,5 @@
+-- version is increased by every change of the movie or the actor and is exposed as ETag for optimistic locking
+ALTER TABLE movies
+    ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+ALTER TABLE actors
+    ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS movies_updated_at_idx;
+
+ALTER TABLE actors
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS created_at;
+ALTER TABLE movies
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS created_at;
@@ -0,0 +1,10 @@
+-- updated_at is set by the repository along with the version, rows that existed before are stamped with the migration time
+ALTER TABLE movies
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE actors
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+-- incremental sync of movies
+CREATE INDEX IF NOT EXISTS movies_updated_at_idx ON movies (updated_at, id) WHERE deleted_at IS NULL;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS movie_actors_movie_id_actor_id_idx;
@@ -0,0 +1,6 @@
+-- an actor is credited once per movie, the earliest credit of a repeated one is kept
+DELETE FROM movie_actors ma
+USING movie_actors earlier
+WHERE earlier.movie_id = ma.movie_id AND earlier.actor_id = ma.actor_id AND earlier.id < ma.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS movie_actors_movie_id_actor_id_idx ON movie_actors (movie_id, actor_id);
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS movies_rating_idx ON movies (rating DESC, id);
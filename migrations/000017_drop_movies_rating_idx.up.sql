@@ -0,0 +1,2 @@
+-- sorting and filtering by rating use the score since 000008, the index only slowed down writes
+DROP INDEX IF EXISTS movies_rating_idx;
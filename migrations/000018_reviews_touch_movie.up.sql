@@ -0,0 +1,22 @@
+-- the score is shown with the movie, so reviews change updated_at as well, the version is left to admin edits
+CREATE OR REPLACE FUNCTION reviews_refresh_movie_score() RETURNS trigger
+    LANGUAGE plpgsql AS
+$$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        UPDATE movies
+        SET review_count      = review_count - 1,
+            review_rating_sum = review_rating_sum - OLD.rating,
+            updated_at        = now()
+        WHERE id = OLD.movie_id;
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        UPDATE movies
+        SET review_count      = review_count + 1,
+            review_rating_sum = review_rating_sum + NEW.rating,
+            updated_at        = now()
+        WHERE id = NEW.movie_id;
+    END IF;
+    RETURN NULL;
+END
+$$;
@@ -0,0 +1 @@
+-- the former billings of credits of actors in the trash aren't kept
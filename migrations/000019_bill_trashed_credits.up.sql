@@ -0,0 +1,13 @@
+-- credits of actors in the trash kept billings the cast could take since, they are billed after the cast,
+-- so restored actors don't share billings with it
+UPDATE movie_actors ma
+SET billing = trashed.billing
+FROM (SELECT t.id,
+             COALESCE(cast_end.billing, 0) + row_number() OVER (PARTITION BY t.movie_id ORDER BY t.billing, t.id) AS billing
+      FROM movie_actors t
+               JOIN actors a ON a.id = t.actor_id AND a.deleted_at IS NOT NULL
+               LEFT JOIN (SELECT c.movie_id, MAX(c.billing) AS billing
+                          FROM movie_actors c
+                                   JOIN actors ca ON ca.id = c.actor_id AND ca.deleted_at IS NULL
+                          GROUP BY c.movie_id) cast_end ON cast_end.movie_id = t.movie_id) trashed
+WHERE ma.id = trashed.id;
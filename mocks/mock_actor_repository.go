@@ -72,17 +72,46 @@ func (mr *MockActorRepositoryMockRecorder) AddActor(ctx, name, gender, birthDate
 }
 
 // DeleteActor mocks base method.
-func (m *MockActorRepository) DeleteActor(ctx context.Context, id int) error {
+func (m *MockActorRepository) DeleteActor(ctx context.Context, id, version int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteActor", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteActor", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteActor indicates an expected call of DeleteActor.
-func (mr *MockActorRepositoryMockRecorder) DeleteActor(ctx, id any) *gomock.Call {
+func (mr *MockActorRepositoryMockRecorder) DeleteActor(ctx, id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActor", reflect.TypeOf((*MockActorRepository)(nil).DeleteActor), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActor", reflect.TypeOf((*MockActorRepository)(nil).DeleteActor), ctx, id, version)
+}
+
+// ExpandCostars mocks base method.
+func (m *MockActorRepository) ExpandCostars(ctx context.Context, actorIds []int) ([]domain.CostarLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpandCostars", ctx, actorIds)
+	ret0, _ := ret[0].([]domain.CostarLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpandCostars indicates an expected call of ExpandCostars.
+func (mr *MockActorRepositoryMockRecorder) ExpandCostars(ctx, actorIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpandCostars", reflect.TypeOf((*MockActorRepository)(nil).ExpandCostars), ctx, actorIds)
+}
+
+// ExportActors mocks base method.
+func (m *MockActorRepository) ExportActors(ctx context.Context, fn func(*domain.Actor) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportActors", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportActors indicates an expected call of ExportActors.
+func (mr *MockActorRepositoryMockRecorder) ExportActors(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportActors", reflect.TypeOf((*MockActorRepository)(nil).ExportActors), ctx, fn)
 }
 
 // GetActorById mocks base method.
@@ -100,19 +129,157 @@ func (mr *MockActorRepositoryMockRecorder) GetActorById(ctx, id any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorById", reflect.TypeOf((*MockActorRepository)(nil).GetActorById), ctx, id)
 }
 
+// GetActorsByIds mocks base method.
+func (m *MockActorRepository) GetActorsByIds(ctx context.Context, ids []int) (map[int]*domain.Actor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActorsByIds", ctx, ids)
+	ret0, _ := ret[0].(map[int]*domain.Actor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActorsByIds indicates an expected call of GetActorsByIds.
+func (mr *MockActorRepositoryMockRecorder) GetActorsByIds(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorsByIds", reflect.TypeOf((*MockActorRepository)(nil).GetActorsByIds), ctx, ids)
+}
+
+// GetMoviesByIds mocks base method.
+func (m *MockActorRepository) GetMoviesByIds(ctx context.Context, ids []int) (map[int]*domain.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMoviesByIds", ctx, ids)
+	ret0, _ := ret[0].(map[int]*domain.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMoviesByIds indicates an expected call of GetMoviesByIds.
+func (mr *MockActorRepositoryMockRecorder) GetMoviesByIds(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMoviesByIds", reflect.TypeOf((*MockActorRepository)(nil).GetMoviesByIds), ctx, ids)
+}
+
+// GetRevision mocks base method.
+func (m *MockActorRepository) GetRevision(ctx context.Context, entity domain.AuditEntity, id, number int) (*domain.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", ctx, entity, id, number)
+	ret0, _ := ret[0].(*domain.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockActorRepositoryMockRecorder) GetRevision(ctx, entity, id, number any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockActorRepository)(nil).GetRevision), ctx, entity, id, number)
+}
+
 // ListActors mocks base method.
-func (m *MockActorRepository) ListActors(ctx context.Context) ([]*domain.Actor, error) {
+func (m *MockActorRepository) ListActors(ctx context.Context, filter domain.ActorFilter, sort domain.ActorSort, page domain.Page) ([]*domain.Actor, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListActors", ctx)
+	ret := m.ctrl.Call(m, "ListActors", ctx, filter, sort, page)
 	ret0, _ := ret[0].([]*domain.Actor)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListActors indicates an expected call of ListActors.
+func (mr *MockActorRepositoryMockRecorder) ListActors(ctx, filter, sort, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActors", reflect.TypeOf((*MockActorRepository)(nil).ListActors), ctx, filter, sort, page)
+}
+
+// ListCostars mocks base method.
+func (m *MockActorRepository) ListCostars(ctx context.Context, actorId int, page domain.Page) ([]*domain.Costar, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCostars", ctx, actorId, page)
+	ret0, _ := ret[0].([]*domain.Costar)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListCostars indicates an expected call of ListCostars.
+func (mr *MockActorRepositoryMockRecorder) ListCostars(ctx, actorId, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCostars", reflect.TypeOf((*MockActorRepository)(nil).ListCostars), ctx, actorId, page)
+}
+
+// ListDeletedActors mocks base method.
+func (m *MockActorRepository) ListDeletedActors(ctx context.Context, page domain.Page) ([]*domain.Actor, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletedActors", ctx, page)
+	ret0, _ := ret[0].([]*domain.Actor)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListDeletedActors indicates an expected call of ListDeletedActors.
+func (mr *MockActorRepositoryMockRecorder) ListDeletedActors(ctx, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedActors", reflect.TypeOf((*MockActorRepository)(nil).ListDeletedActors), ctx, page)
+}
+
+// ListRevisions mocks base method.
+func (m *MockActorRepository) ListRevisions(ctx context.Context, entity domain.AuditEntity, id int, page domain.Page) ([]*domain.Revision, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", ctx, entity, id, page)
+	ret0, _ := ret[0].([]*domain.Revision)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockActorRepositoryMockRecorder) ListRevisions(ctx, entity, id, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockActorRepository)(nil).ListRevisions), ctx, entity, id, page)
+}
+
+// LoadFilmographies mocks base method.
+func (m *MockActorRepository) LoadFilmographies(ctx context.Context, actors []*domain.Actor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadFilmographies", ctx, actors)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LoadFilmographies indicates an expected call of LoadFilmographies.
+func (mr *MockActorRepositoryMockRecorder) LoadFilmographies(ctx, actors any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadFilmographies", reflect.TypeOf((*MockActorRepository)(nil).LoadFilmographies), ctx, actors)
+}
+
+// PurgeActor mocks base method.
+func (m *MockActorRepository) PurgeActor(ctx context.Context, id int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeActor", ctx, id)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListActors indicates an expected call of ListActors.
-func (mr *MockActorRepositoryMockRecorder) ListActors(ctx any) *gomock.Call {
+// PurgeActor indicates an expected call of PurgeActor.
+func (mr *MockActorRepositoryMockRecorder) PurgeActor(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeActor", reflect.TypeOf((*MockActorRepository)(nil).PurgeActor), ctx, id)
+}
+
+// RestoreActor mocks base method.
+func (m *MockActorRepository) RestoreActor(ctx context.Context, id int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreActor", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreActor indicates an expected call of RestoreActor.
+func (mr *MockActorRepositoryMockRecorder) RestoreActor(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActors", reflect.TypeOf((*MockActorRepository)(nil).ListActors), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreActor", reflect.TypeOf((*MockActorRepository)(nil).RestoreActor), ctx, id)
 }
 
 // UpdateActor mocks base method.
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/collection_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/collection_repository.go -destination=mocks/mock_collection_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "vk-backend/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCollectionRepository is a mock of CollectionRepository interface.
+type MockCollectionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCollectionRepositoryMockRecorder
+}
+
+// MockCollectionRepositoryMockRecorder is the mock recorder for MockCollectionRepository.
+type MockCollectionRepositoryMockRecorder struct {
+	mock *MockCollectionRepository
+}
+
+// NewMockCollectionRepository creates a new mock instance.
+func NewMockCollectionRepository(ctrl *gomock.Controller) *MockCollectionRepository {
+	mock := &MockCollectionRepository{ctrl: ctrl}
+	mock.recorder = &MockCollectionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCollectionRepository) EXPECT() *MockCollectionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddToCollection mocks base method.
+func (m *MockCollectionRepository) AddToCollection(ctx context.Context, c domain.UserCollection, movieId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToCollection", ctx, c, movieId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddToCollection indicates an expected call of AddToCollection.
+func (mr *MockCollectionRepositoryMockRecorder) AddToCollection(ctx, c, movieId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToCollection", reflect.TypeOf((*MockCollectionRepository)(nil).AddToCollection), ctx, c, movieId)
+}
+
+// InCollection mocks base method.
+func (m *MockCollectionRepository) InCollection(ctx context.Context, c domain.UserCollection, movieIds []int) (map[int]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InCollection", ctx, c, movieIds)
+	ret0, _ := ret[0].(map[int]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InCollection indicates an expected call of InCollection.
+func (mr *MockCollectionRepositoryMockRecorder) InCollection(ctx, c, movieIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InCollection", reflect.TypeOf((*MockCollectionRepository)(nil).InCollection), ctx, c, movieIds)
+}
+
+// MovieExists mocks base method.
+func (m *MockCollectionRepository) MovieExists(ctx context.Context, id int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MovieExists", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MovieExists indicates an expected call of MovieExists.
+func (mr *MockCollectionRepositoryMockRecorder) MovieExists(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MovieExists", reflect.TypeOf((*MockCollectionRepository)(nil).MovieExists), ctx, id)
+}
+
+// RemoveFromCollection mocks base method.
+func (m *MockCollectionRepository) RemoveFromCollection(ctx context.Context, c domain.UserCollection, movieId int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFromCollection", ctx, c, movieId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveFromCollection indicates an expected call of RemoveFromCollection.
+func (mr *MockCollectionRepositoryMockRecorder) RemoveFromCollection(ctx, c, movieId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromCollection", reflect.TypeOf((*MockCollectionRepository)(nil).RemoveFromCollection), ctx, c, movieId)
+}
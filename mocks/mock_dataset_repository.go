@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/dataset_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/dataset_repository.go -destination=mocks/mock_dataset_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "vk-backend/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDatasetRepository is a mock of DatasetRepository interface.
+type MockDatasetRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDatasetRepositoryMockRecorder
+}
+
+// MockDatasetRepositoryMockRecorder is the mock recorder for MockDatasetRepository.
+type MockDatasetRepositoryMockRecorder struct {
+	mock *MockDatasetRepository
+}
+
+// NewMockDatasetRepository creates a new mock instance.
+func NewMockDatasetRepository(ctrl *gomock.Controller) *MockDatasetRepository {
+	mock := &MockDatasetRepository{ctrl: ctrl}
+	mock.recorder = &MockDatasetRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDatasetRepository) EXPECT() *MockDatasetRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetCheckpoint mocks base method.
+func (m *MockDatasetRepository) GetCheckpoint(ctx context.Context, source string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCheckpoint", ctx, source)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCheckpoint indicates an expected call of GetCheckpoint.
+func (mr *MockDatasetRepositoryMockRecorder) GetCheckpoint(ctx, source any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckpoint", reflect.TypeOf((*MockDatasetRepository)(nil).GetCheckpoint), ctx, source)
+}
+
+// ResetCheckpoints mocks base method.
+func (m *MockDatasetRepository) ResetCheckpoints(ctx context.Context, sources []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetCheckpoints", ctx, sources)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetCheckpoints indicates an expected call of ResetCheckpoints.
+func (mr *MockDatasetRepositoryMockRecorder) ResetCheckpoints(ctx, sources any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetCheckpoints", reflect.TypeOf((*MockDatasetRepository)(nil).ResetCheckpoints), ctx, sources)
+}
+
+// UpsertExternalActors mocks base method.
+func (m *MockDatasetRepository) UpsertExternalActors(ctx context.Context, actors []*domain.ExternalActor, cp domain.Checkpoint) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertExternalActors", ctx, actors, cp)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertExternalActors indicates an expected call of UpsertExternalActors.
+func (mr *MockDatasetRepositoryMockRecorder) UpsertExternalActors(ctx, actors, cp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertExternalActors", reflect.TypeOf((*MockDatasetRepository)(nil).UpsertExternalActors), ctx, actors, cp)
+}
+
+// UpsertExternalCredits mocks base method.
+func (m *MockDatasetRepository) UpsertExternalCredits(ctx context.Context, credits []*domain.ExternalCredit, cp domain.Checkpoint) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertExternalCredits", ctx, credits, cp)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertExternalCredits indicates an expected call of UpsertExternalCredits.
+func (mr *MockDatasetRepositoryMockRecorder) UpsertExternalCredits(ctx, credits, cp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertExternalCredits", reflect.TypeOf((*MockDatasetRepository)(nil).UpsertExternalCredits), ctx, credits, cp)
+}
+
+// UpsertExternalMovies mocks base method.
+func (m *MockDatasetRepository) UpsertExternalMovies(ctx context.Context, movies []*domain.ExternalMovie, cp domain.Checkpoint) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertExternalMovies", ctx, movies, cp)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertExternalMovies indicates an expected call of UpsertExternalMovies.
+func (mr *MockDatasetRepositoryMockRecorder) UpsertExternalMovies(ctx, movies, cp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertExternalMovies", reflect.TypeOf((*MockDatasetRepository)(nil).UpsertExternalMovies), ctx, movies, cp)
+}
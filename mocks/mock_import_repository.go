@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/import_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/import_repository.go -destination=mocks/mock_import_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "vk-backend/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImportRepository is a mock of ImportRepository interface.
+type MockImportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportRepositoryMockRecorder
+}
+
+// MockImportRepositoryMockRecorder is the mock recorder for MockImportRepository.
+type MockImportRepositoryMockRecorder struct {
+	mock *MockImportRepository
+}
+
+// NewMockImportRepository creates a new mock instance.
+func NewMockImportRepository(ctrl *gomock.Controller) *MockImportRepository {
+	mock := &MockImportRepository{ctrl: ctrl}
+	mock.recorder = &MockImportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportRepository) EXPECT() *MockImportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindActorsByNameAndBirthDate mocks base method.
+func (m *MockImportRepository) FindActorsByNameAndBirthDate(ctx context.Context, actors []*domain.Actor) ([]*domain.Actor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActorsByNameAndBirthDate", ctx, actors)
+	ret0, _ := ret[0].([]*domain.Actor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActorsByNameAndBirthDate indicates an expected call of FindActorsByNameAndBirthDate.
+func (mr *MockImportRepositoryMockRecorder) FindActorsByNameAndBirthDate(ctx, actors any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActorsByNameAndBirthDate", reflect.TypeOf((*MockImportRepository)(nil).FindActorsByNameAndBirthDate), ctx, actors)
+}
+
+// GetActorsByIds mocks base method.
+func (m *MockImportRepository) GetActorsByIds(ctx context.Context, ids []int) (map[int]*domain.Actor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActorsByIds", ctx, ids)
+	ret0, _ := ret[0].(map[int]*domain.Actor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActorsByIds indicates an expected call of GetActorsByIds.
+func (mr *MockImportRepositoryMockRecorder) GetActorsByIds(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorsByIds", reflect.TypeOf((*MockImportRepository)(nil).GetActorsByIds), ctx, ids)
+}
+
+// ImportCatalog mocks base method.
+func (m *MockImportRepository) ImportCatalog(ctx context.Context, actors []*domain.Actor, movies []*domain.Movie) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportCatalog", ctx, actors, movies)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportCatalog indicates an expected call of ImportCatalog.
+func (mr *MockImportRepositoryMockRecorder) ImportCatalog(ctx, actors, movies any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportCatalog", reflect.TypeOf((*MockImportRepository)(nil).ImportCatalog), ctx, actors, movies)
+}
@@ -57,46 +57,61 @@ func (mr *MockMovieRepositoryMockRecorder) ActorExists(ctx, id any) *gomock.Call
 }
 
 // AddActorToMovie mocks base method.
-func (m *MockMovieRepository) AddActorToMovie(ctx context.Context, actorId, movieId int) error {
+func (m *MockMovieRepository) AddActorToMovie(ctx context.Context, actorId, movieId int, character string, billing, version int) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddActorToMovie", ctx, actorId, movieId)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "AddActorToMovie", ctx, actorId, movieId, character, billing, version)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // AddActorToMovie indicates an expected call of AddActorToMovie.
-func (mr *MockMovieRepositoryMockRecorder) AddActorToMovie(ctx, actorId, movieId any) *gomock.Call {
+func (mr *MockMovieRepositoryMockRecorder) AddActorToMovie(ctx, actorId, movieId, character, billing, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddActorToMovie", reflect.TypeOf((*MockMovieRepository)(nil).AddActorToMovie), ctx, actorId, movieId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddActorToMovie", reflect.TypeOf((*MockMovieRepository)(nil).AddActorToMovie), ctx, actorId, movieId, character, billing, version)
 }
 
 // AddMovie mocks base method.
-func (m *MockMovieRepository) AddMovie(ctx context.Context, title, description string, releaseDate time.Time, rating float64, actors []*domain.Actor) (*domain.Movie, error) {
+func (m *MockMovieRepository) AddMovie(ctx context.Context, title, description string, releaseDate time.Time, rating float64, actors []*domain.CastMember, genres []*domain.Genre) (*domain.Movie, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddMovie", ctx, title, description, releaseDate, rating, actors)
+	ret := m.ctrl.Call(m, "AddMovie", ctx, title, description, releaseDate, rating, actors, genres)
 	ret0, _ := ret[0].(*domain.Movie)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddMovie indicates an expected call of AddMovie.
-func (mr *MockMovieRepositoryMockRecorder) AddMovie(ctx, title, description, releaseDate, rating, actors any) *gomock.Call {
+func (mr *MockMovieRepositoryMockRecorder) AddMovie(ctx, title, description, releaseDate, rating, actors, genres any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMovie", reflect.TypeOf((*MockMovieRepository)(nil).AddMovie), ctx, title, description, releaseDate, rating, actors)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMovie", reflect.TypeOf((*MockMovieRepository)(nil).AddMovie), ctx, title, description, releaseDate, rating, actors, genres)
 }
 
 // DeleteMovie mocks base method.
-func (m *MockMovieRepository) DeleteMovie(ctx context.Context, id int) error {
+func (m *MockMovieRepository) DeleteMovie(ctx context.Context, id, version int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteMovie", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteMovie", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteMovie indicates an expected call of DeleteMovie.
-func (mr *MockMovieRepositoryMockRecorder) DeleteMovie(ctx, id any) *gomock.Call {
+func (mr *MockMovieRepositoryMockRecorder) DeleteMovie(ctx, id, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMovie", reflect.TypeOf((*MockMovieRepository)(nil).DeleteMovie), ctx, id, version)
+}
+
+// ExportMovies mocks base method.
+func (m *MockMovieRepository) ExportMovies(ctx context.Context, fn func(*domain.Movie) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportMovies", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportMovies indicates an expected call of ExportMovies.
+func (mr *MockMovieRepositoryMockRecorder) ExportMovies(ctx, fn any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMovie", reflect.TypeOf((*MockMovieRepository)(nil).DeleteMovie), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportMovies", reflect.TypeOf((*MockMovieRepository)(nil).ExportMovies), ctx, fn)
 }
 
 // GetActorsByMovieId mocks base method.
@@ -129,19 +144,67 @@ func (mr *MockMovieRepositoryMockRecorder) GetMovieById(ctx, id any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMovieById", reflect.TypeOf((*MockMovieRepository)(nil).GetMovieById), ctx, id)
 }
 
-// ListMovies mocks base method.
-func (m *MockMovieRepository) ListMovies(ctx context.Context) ([]*domain.Movie, error) {
+// GetRevision mocks base method.
+func (m *MockMovieRepository) GetRevision(ctx context.Context, entity domain.AuditEntity, id, number int) (*domain.Revision, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListMovies", ctx)
-	ret0, _ := ret[0].([]*domain.Movie)
+	ret := m.ctrl.Call(m, "GetRevision", ctx, entity, id, number)
+	ret0, _ := ret[0].(*domain.Revision)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockMovieRepositoryMockRecorder) GetRevision(ctx, entity, id, number any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockMovieRepository)(nil).GetRevision), ctx, entity, id, number)
+}
+
+// ListDeletedMovies mocks base method.
+func (m *MockMovieRepository) ListDeletedMovies(ctx context.Context, page domain.Page) ([]*domain.Movie, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletedMovies", ctx, page)
+	ret0, _ := ret[0].([]*domain.Movie)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListDeletedMovies indicates an expected call of ListDeletedMovies.
+func (mr *MockMovieRepositoryMockRecorder) ListDeletedMovies(ctx, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedMovies", reflect.TypeOf((*MockMovieRepository)(nil).ListDeletedMovies), ctx, page)
+}
+
+// ListMovies mocks base method.
+func (m *MockMovieRepository) ListMovies(ctx context.Context, filter domain.MovieFilter, sort domain.MovieSort, page domain.Page) ([]*domain.Movie, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMovies", ctx, filter, sort, page)
+	ret0, _ := ret[0].([]*domain.Movie)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // ListMovies indicates an expected call of ListMovies.
-func (mr *MockMovieRepositoryMockRecorder) ListMovies(ctx any) *gomock.Call {
+func (mr *MockMovieRepositoryMockRecorder) ListMovies(ctx, filter, sort, page any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMovies", reflect.TypeOf((*MockMovieRepository)(nil).ListMovies), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMovies", reflect.TypeOf((*MockMovieRepository)(nil).ListMovies), ctx, filter, sort, page)
+}
+
+// ListRevisions mocks base method.
+func (m *MockMovieRepository) ListRevisions(ctx context.Context, entity domain.AuditEntity, id int, page domain.Page) ([]*domain.Revision, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", ctx, entity, id, page)
+	ret0, _ := ret[0].([]*domain.Revision)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockMovieRepositoryMockRecorder) ListRevisions(ctx, entity, id, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockMovieRepository)(nil).ListRevisions), ctx, entity, id, page)
 }
 
 // MovieExists mocks base method.
@@ -159,6 +222,96 @@ func (mr *MockMovieRepositoryMockRecorder) MovieExists(ctx, id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MovieExists", reflect.TypeOf((*MockMovieRepository)(nil).MovieExists), ctx, id)
 }
 
+// PurgeMovie mocks base method.
+func (m *MockMovieRepository) PurgeMovie(ctx context.Context, id int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeMovie", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeMovie indicates an expected call of PurgeMovie.
+func (mr *MockMovieRepositoryMockRecorder) PurgeMovie(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeMovie", reflect.TypeOf((*MockMovieRepository)(nil).PurgeMovie), ctx, id)
+}
+
+// RemoveActorFromMovie mocks base method.
+func (m *MockMovieRepository) RemoveActorFromMovie(ctx context.Context, actorId, movieId, version int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveActorFromMovie", ctx, actorId, movieId, version)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveActorFromMovie indicates an expected call of RemoveActorFromMovie.
+func (mr *MockMovieRepositoryMockRecorder) RemoveActorFromMovie(ctx, actorId, movieId, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveActorFromMovie", reflect.TypeOf((*MockMovieRepository)(nil).RemoveActorFromMovie), ctx, actorId, movieId, version)
+}
+
+// ReplaceCast mocks base method.
+func (m *MockMovieRepository) ReplaceCast(ctx context.Context, movieId int, cast []*domain.CastMember, version int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceCast", ctx, movieId, cast, version)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceCast indicates an expected call of ReplaceCast.
+func (mr *MockMovieRepositoryMockRecorder) ReplaceCast(ctx, movieId, cast, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceCast", reflect.TypeOf((*MockMovieRepository)(nil).ReplaceCast), ctx, movieId, cast, version)
+}
+
+// RestoreMovie mocks base method.
+func (m *MockMovieRepository) RestoreMovie(ctx context.Context, id int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreMovie", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreMovie indicates an expected call of RestoreMovie.
+func (mr *MockMovieRepositoryMockRecorder) RestoreMovie(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreMovie", reflect.TypeOf((*MockMovieRepository)(nil).RestoreMovie), ctx, id)
+}
+
+// SimilarMovies mocks base method.
+func (m *MockMovieRepository) SimilarMovies(ctx context.Context, movieId, limit int) ([]*domain.Movie, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SimilarMovies", ctx, movieId, limit)
+	ret0, _ := ret[0].([]*domain.Movie)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SimilarMovies indicates an expected call of SimilarMovies.
+func (mr *MockMovieRepositoryMockRecorder) SimilarMovies(ctx, movieId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimilarMovies", reflect.TypeOf((*MockMovieRepository)(nil).SimilarMovies), ctx, movieId, limit)
+}
+
+// SuggestSpellings mocks base method.
+func (m *MockMovieRepository) SuggestSpellings(ctx context.Context, term string, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestSpellings", ctx, term, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestSpellings indicates an expected call of SuggestSpellings.
+func (mr *MockMovieRepositoryMockRecorder) SuggestSpellings(ctx, term, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestSpellings", reflect.TypeOf((*MockMovieRepository)(nil).SuggestSpellings), ctx, term, limit)
+}
+
 // UpdateMovie mocks base method.
 func (m *MockMovieRepository) UpdateMovie(ctx context.Context, new *domain.Movie) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/stats_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/stats_repository.go -destination=mocks/mock_stats_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "vk-backend/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStatsRepository is a mock of StatsRepository interface.
+type MockStatsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatsRepositoryMockRecorder
+}
+
+// MockStatsRepositoryMockRecorder is the mock recorder for MockStatsRepository.
+type MockStatsRepositoryMockRecorder struct {
+	mock *MockStatsRepository
+}
+
+// NewMockStatsRepository creates a new mock instance.
+func NewMockStatsRepository(ctrl *gomock.Controller) *MockStatsRepository {
+	mock := &MockStatsRepository{ctrl: ctrl}
+	mock.recorder = &MockStatsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatsRepository) EXPECT() *MockStatsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ActorAges mocks base method.
+func (m *MockStatsRepository) ActorAges(ctx context.Context, actorId *int) ([]domain.AgeCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActorAges", ctx, actorId)
+	ret0, _ := ret[0].([]domain.AgeCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActorAges indicates an expected call of ActorAges.
+func (mr *MockStatsRepositoryMockRecorder) ActorAges(ctx, actorId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActorAges", reflect.TypeOf((*MockStatsRepository)(nil).ActorAges), ctx, actorId)
+}
+
+// ActorExists mocks base method.
+func (m *MockStatsRepository) ActorExists(ctx context.Context, id int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActorExists", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActorExists indicates an expected call of ActorExists.
+func (mr *MockStatsRepositoryMockRecorder) ActorExists(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActorExists", reflect.TypeOf((*MockStatsRepository)(nil).ActorExists), ctx, id)
+}
+
+// MoviesByYear mocks base method.
+func (m *MockStatsRepository) MoviesByYear(ctx context.Context) ([]*domain.YearStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoviesByYear", ctx)
+	ret0, _ := ret[0].([]*domain.YearStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoviesByYear indicates an expected call of MoviesByYear.
+func (mr *MockStatsRepositoryMockRecorder) MoviesByYear(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoviesByYear", reflect.TypeOf((*MockStatsRepository)(nil).MoviesByYear), ctx)
+}
+
+// ProlificActors mocks base method.
+func (m *MockStatsRepository) ProlificActors(ctx context.Context, limit int) ([]*domain.ProlificActor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProlificActors", ctx, limit)
+	ret0, _ := ret[0].([]*domain.ProlificActor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProlificActors indicates an expected call of ProlificActors.
+func (mr *MockStatsRepositoryMockRecorder) ProlificActors(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProlificActors", reflect.TypeOf((*MockStatsRepository)(nil).ProlificActors), ctx, limit)
+}
+
+// RatingHistogram mocks base method.
+func (m *MockStatsRepository) RatingHistogram(ctx context.Context, buckets int) ([]*domain.RatingBucket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RatingHistogram", ctx, buckets)
+	ret0, _ := ret[0].([]*domain.RatingBucket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RatingHistogram indicates an expected call of RatingHistogram.
+func (mr *MockStatsRepositoryMockRecorder) RatingHistogram(ctx, buckets any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RatingHistogram", reflect.TypeOf((*MockStatsRepository)(nil).RatingHistogram), ctx, buckets)
+}